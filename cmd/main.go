@@ -9,6 +9,7 @@ import (
 	"database/sql"
 
 	"github.com/FerNunez/NameThatSong/internal/handlers"
+	"github.com/FerNunez/NameThatSong/internal/library"
 	"github.com/FerNunez/NameThatSong/internal/manager"
 	"github.com/FerNunez/NameThatSong/internal/store"
 	"github.com/FerNunez/NameThatSong/internal/store/database"
@@ -35,7 +36,22 @@ func main() {
 	dbQueries := database.New(db)
 	userStore := store.NewSQLUserStore(dbQueries)
 
-	gm := manager.NewGameManager()
+	// Play from a local music library instead of Spotify when configured
+	providerFactory := manager.NewSpotifyProviderFactory()
+	if libraryDir := os.Getenv("MUSIC_LIBRARY_DIR"); libraryDir != "" {
+		lib, err := library.NewLibrary(libraryDir)
+		if err != nil {
+			log.Fatalf("Error loading music library: %v", err)
+		}
+		for _, err := range lib.Skipped {
+			log.Printf("Skipped audio file: %v", err)
+		}
+		providerFactory = manager.NewLibraryProviderFactory(lib)
+	}
+	gm := manager.NewGameManager(providerFactory)
+	gm.SettingsStore = store.NewSQLSettingsStore(dbQueries)
+	gm.ScoreStore = store.NewSQLScoreStore(dbQueries)
+	gm.HistoryStore = store.NewSQLHistoryStore(dbQueries)
 
 	// Create new router
 	r := chi.NewRouter()
@@ -65,21 +81,45 @@ func main() {
 		// Search
 		r.Get("/search-helper", handlers.NewGetSearchArtists(gm).ServeHttp)
 		r.Get("/search-albums", handlers.NewGetArtistAlbums(gm).ServeHttp)
+		r.Get("/playlists", handlers.NewGetPlaylists(gm).ServeHttp)
 
 		// Select
 		r.Post("/api/select-album", handlers.NewPostSelectAlbum(gm).ServeHttp)
+		r.Post("/api/select-playlist", handlers.NewPostSelectPlaylist(gm).ServeHttp)
+		r.Post("/api/select-source", handlers.NewPostSelectSource(gm).ServeHttp)
 		r.Post("/start-game", handlers.NewPostStartGame(gm).ServeHttp)
 
 		// Guess
 		r.Post("/guess-track", handlers.NewPostGuessTrack(gm).ServeHttp)
+		r.Post("/choose", handlers.NewPostChooseTrack(gm).ServeHttp)
 
 		// Player
 		r.Post("/play-pause", handlers.NewPostPlayPause(gm).ServeHttp)
 		r.Post("/skip", handlers.NewPostSkip(gm).ServeHttp)
 		r.Post("/clear-queue", handlers.NewPostClearQueue(gm).ServeHttp)
+		r.Get("/devices", handlers.NewGetDevices(gm).ServeHttp)
+		r.Post("/api/select-device", handlers.NewPostSelectDevice(gm).ServeHttp)
+		r.Post("/reveal", handlers.NewPostReveal(gm).ServeHttp)
+		r.Post("/hint", handlers.NewPostHint(gm).ServeHttp)
+		r.Get("/summary", handlers.NewGetSummary(gm).ServeHttp)
+		r.Post("/play-again", handlers.NewPostPlayAgain(gm).ServeHttp)
+		r.Post("/deadline", handlers.NewPostDeadline(gm).ServeHttp)
+		r.Post("/replay", handlers.NewPostReplay(gm).ServeHttp)
+
+		// History
+		r.Get("/history", handlers.NewGetHistory(gm).ServeHttp)
+		r.Get("/history/{gameId}", handlers.NewGetHistoryGame(gm).ServeHttp)
+
+		// Web Playback SDK
+		r.Get("/player/token", handlers.NewGetPlayerToken(gm).ServeHttp)
+		r.Post("/player/device", handlers.NewPostPlayerDevice(gm).ServeHttp)
 
 		//r.Get("/song-time", handlers.NewGetSongTime(gm).ServeHttp)
 
+		// Local music library
+		r.Get("/library/stream", handlers.NewGetLibraryStream(gm).ServeHttp)
+		r.Get("/library/cover/{albumId}", handlers.NewGetLibraryCover(gm).ServeHttp)
+
 	})
 
 	// Start the server
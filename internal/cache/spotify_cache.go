@@ -1,91 +1,135 @@
 package cache
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/FerNunez/NameThatSong/internal/spotify_api"
 )
 
 type SpotifyCache struct {
-	ArtistMap         map[string]spotify_api.ArtistData
-	ArtistToAlbumsMap map[string][]string
-	AlbumMap          map[string]spotify_api.AlbumData
-	AlbumToTracksMap  map[string][]string
-	TrackMap          map[string]spotify_api.TrackData
-	TrackIdToAlbumId  map[string]string
-	AlbumIdToArtistId map[string]string
+	ArtistMap              map[string]spotify_api.ArtistData
+	ArtistToAlbumsMap      map[string][]string
+	ArtistGroupToAlbumsMap map[string]map[string][]string // artist -> album group -> albums
+	AlbumMap               map[string]spotify_api.AlbumData
+	AlbumToTracksMap       map[string][]string
+	TrackMap               map[string]spotify_api.TrackData
+	TrackIdToAlbumId       map[string]string
+	TrackIdToArtistId      map[string]string
+	AlbumIdToArtistId      map[string]string
+	PlaylistMap            map[string]spotify_api.PlaylistData
+	PlaylistToTracksMap    map[string][]string
+	SourceToTracksMap      map[string][]string // liked songs and top tracks/artists -> tracks
 }
 
 func NewSpotifyCache() *SpotifyCache {
 	return &SpotifyCache{
-		ArtistMap:         map[string]spotify_api.ArtistData{},
-		ArtistToAlbumsMap: map[string][]string{},
-		AlbumMap:          map[string]spotify_api.AlbumData{},
-		AlbumToTracksMap:  map[string][]string{},
-		TrackMap:          map[string]spotify_api.TrackData{},
-		TrackIdToAlbumId:  make(map[string]string),
-		AlbumIdToArtistId: make(map[string]string),
+		ArtistMap:              map[string]spotify_api.ArtistData{},
+		ArtistToAlbumsMap:      map[string][]string{},
+		ArtistGroupToAlbumsMap: map[string]map[string][]string{},
+		AlbumMap:               map[string]spotify_api.AlbumData{},
+		AlbumToTracksMap:       map[string][]string{},
+		TrackMap:               map[string]spotify_api.TrackData{},
+		TrackIdToAlbumId:       make(map[string]string),
+		TrackIdToArtistId:      make(map[string]string),
+		AlbumIdToArtistId:      make(map[string]string),
+		PlaylistMap:            map[string]spotify_api.PlaylistData{},
+		PlaylistToTracksMap:    map[string][]string{},
+		SourceToTracksMap:      map[string][]string{},
 	}
 }
 
-func (c *SpotifyCache) GetArtistData(s *spotify_api.SpotifySongProvider, id string) (spotify_api.ArtistData, error) {
+func (c *SpotifyCache) GetArtistData(ctx context.Context, s spotify_api.SongProvider, id string) (spotify_api.ArtistData, error) {
 	return spotify_api.ArtistData{}, nil
 }
 
-func (c *SpotifyCache) GetArtistsAlbum(s *spotify_api.SpotifySongProvider, accessToken, artistId string) ([]spotify_api.AlbumData, error) {
-	// check if artist already known
-	albumsIds, exist := c.ArtistToAlbumsMap[artistId]
+// GetArtistsAlbum returns the top tracks album of the artist followed by its
+// albums of the given groups. Each group is fetched once and cached
+func (c *SpotifyCache) GetArtistsAlbum(ctx context.Context, s spotify_api.SongProvider, accessToken, artistId string, groups []string) ([]spotify_api.AlbumData, error) {
+	err := c.cacheTopTracksAlbum(ctx, s, accessToken, artistId)
+	if err != nil {
+		return nil, err
+	}
 
-	if !exist {
-		// get artist trop track
-		albumTopTrack, topTracks, err := s.CreateAlbumFromTopTracks(accessToken, artistId)
-		artist, ok := c.ArtistMap[artistId]
-		albumTopTrack.ImagesURL = artist.ImageUrl
-		if !ok {
-			panic("Track should always exist in cache")
-		}
-		if err != nil {
-			return nil, err
+	// fetch the groups not cached yet in a single call
+	groupToAlbums := c.ArtistGroupToAlbumsMap[artistId]
+	missingGroups := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if _, cached := groupToAlbums[group]; !cached {
+			missingGroups = append(missingGroups, group)
 		}
+	}
 
-		albums, err := s.FetchAlbumByArtistID(accessToken, artistId)
+	if len(missingGroups) > 0 {
+		albums, err := s.FetchAlbumByArtistID(ctx, accessToken, artistId, missingGroups)
 		if err != nil {
 			return nil, err
 		}
 
-		// update Artist to albumMaps
-		albumsIds = make([]string, 0, len(albums)+1)
-		albumsIds = append(albumsIds, albumTopTrack.ID)
-		c.AlbumMap[albumTopTrack.ID] = albumTopTrack
+		for _, group := range missingGroups {
+			groupToAlbums[group] = []string{}
+		}
 		for _, album := range albums {
 			c.AlbumMap[album.ID] = album
-			albumsIds = append(albumsIds, album.ID)
 			c.AlbumIdToArtistId[album.ID] = artistId
+			groupToAlbums[album.AlbumGroup] = append(groupToAlbums[album.AlbumGroup], album.ID)
+			c.ArtistToAlbumsMap[artistId] = append(c.ArtistToAlbumsMap[artistId], album.ID)
 		}
+	}
 
-		c.ArtistToAlbumsMap[artistId] = albumsIds
-
-		// associate AlbumID for top tracks
-		tracksIds := make([]string, 0, len(topTracks))
-		for _, track := range topTracks {
-			c.TrackMap[track.ID] = track
-			tracksIds = append(tracksIds, track.ID)
-		}
-		c.AlbumToTracksMap[albumTopTrack.ID] = tracksIds
+	// top tracks first, then the requested groups
+	albumsIds := []string{c.ArtistToAlbumsMap[artistId][0]}
+	for _, group := range groups {
+		albumsIds = append(albumsIds, groupToAlbums[group]...)
 	}
 
 	albums := make([]spotify_api.AlbumData, 0, len(albumsIds))
 	for _, albumId := range albumsIds {
-		album, _ := c.AlbumMap[albumId]
-		albums = append(albums, album)
-
+		albums = append(albums, c.AlbumMap[albumId])
 	}
 
 	return albums, nil
 }
 
-func (c *SpotifyCache) GetAlbumTracks(s *spotify_api.SpotifySongProvider, accessToken, albumId string) ([]spotify_api.TrackData, error) {
+// cacheTopTracksAlbum stores the top tracks of the artist as a fake album,
+// the first of ArtistToAlbumsMap, the first time the artist is seen
+func (c *SpotifyCache) cacheTopTracksAlbum(ctx context.Context, s spotify_api.SongProvider, accessToken, artistId string) error {
+	// check if artist already known
+	_, exist := c.ArtistToAlbumsMap[artistId]
+	if exist {
+		return nil
+	}
+
+	// get artist trop track
+	albumTopTrack, topTracks, err := s.CreateAlbumFromTopTracks(ctx, accessToken, artistId)
+	if err != nil {
+		return err
+	}
+	artist, ok := c.ArtistMap[artistId]
+	if !ok {
+		return fmt.Errorf("artist %v is not cached", artistId)
+	}
+	albumTopTrack.ImagesURL = artist.ImageUrl
+
+	// update Artist to albumMaps
+	c.AlbumMap[albumTopTrack.ID] = albumTopTrack
+	c.ArtistToAlbumsMap[artistId] = []string{albumTopTrack.ID}
+	c.ArtistGroupToAlbumsMap[artistId] = make(map[string][]string)
+
+	// associate AlbumID for top tracks
+	tracksIds := make([]string, 0, len(topTracks))
+	for _, track := range topTracks {
+		c.TrackMap[track.ID] = track
+		tracksIds = append(tracksIds, track.ID)
+	}
+	c.AlbumToTracksMap[albumTopTrack.ID] = tracksIds
+	return nil
+}
+
+func (c *SpotifyCache) GetAlbumTracks(ctx context.Context, s spotify_api.SongProvider, accessToken, albumId string) ([]spotify_api.TrackData, error) {
 	tracksIds, exist := c.AlbumToTracksMap[albumId]
 	if !exist {
-		tracks, err := s.FetchTracksByAlbumID(accessToken, albumId)
+		tracks, err := s.FetchTracksByAlbumID(ctx, accessToken, albumId)
 		if err != nil {
 			return nil, err
 		}
@@ -106,3 +150,149 @@ func (c *SpotifyCache) GetAlbumTracks(s *spotify_api.SpotifySongProvider, access
 	}
 	return tracks, nil
 }
+
+// GetUserPlaylists always asks the provider, as the user may have created
+// playlists since the last call
+func (c *SpotifyCache) GetUserPlaylists(ctx context.Context, s spotify_api.SongProvider, accessToken string) ([]spotify_api.PlaylistData, error) {
+	playlists, err := s.FetchUserPlaylists(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	for _, playlist := range playlists {
+		c.PlaylistMap[playlist.ID] = playlist
+	}
+	return playlists, nil
+}
+
+func (c *SpotifyCache) GetPlaylist(ctx context.Context, s spotify_api.SongProvider, accessToken, playlistId string) (spotify_api.PlaylistData, error) {
+	playlist, exist := c.PlaylistMap[playlistId]
+	if !exist {
+		var err error
+		playlist, err = s.FetchPlaylist(ctx, accessToken, playlistId)
+		if err != nil {
+			return spotify_api.PlaylistData{}, err
+		}
+		c.PlaylistMap[playlistId] = playlist
+	}
+	return playlist, nil
+}
+
+// GetPlaylistTracks returns the tracks of a playlist together with their real
+// album and primary artist, which are cached like any other album and artist
+func (c *SpotifyCache) GetPlaylistTracks(ctx context.Context, s spotify_api.SongProvider, accessToken, playlistId string) ([]spotify_api.PlaylistTrack, error) {
+	tracksIds, exist := c.PlaylistToTracksMap[playlistId]
+	if !exist {
+		playlistTracks, err := s.FetchPlaylistTracks(ctx, accessToken, playlistId)
+		if err != nil {
+			return nil, err
+		}
+		tracksIds = c.storePlaylistTracks(playlistTracks)
+		c.PlaylistToTracksMap[playlistId] = tracksIds
+	}
+	return c.playlistTracks(tracksIds), nil
+}
+
+// GetSavedTracks returns the tracks in the user's Liked Songs
+func (c *SpotifyCache) GetSavedTracks(ctx context.Context, s spotify_api.SongProvider, accessToken string) ([]spotify_api.PlaylistTrack, error) {
+	source := "saved"
+	tracksIds, exist := c.SourceToTracksMap[source]
+	if !exist {
+		playlistTracks, err := s.FetchSavedTracks(ctx, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		tracksIds = c.storePlaylistTracks(playlistTracks)
+		c.SourceToTracksMap[source] = tracksIds
+	}
+	return c.playlistTracks(tracksIds), nil
+}
+
+// GetTopTracks returns the user's most listened tracks over the time range
+func (c *SpotifyCache) GetTopTracks(ctx context.Context, s spotify_api.SongProvider, accessToken, timeRange string) ([]spotify_api.PlaylistTrack, error) {
+	source := "top-tracks:" + timeRange
+	tracksIds, exist := c.SourceToTracksMap[source]
+	if !exist {
+		playlistTracks, err := s.FetchTopTracks(ctx, accessToken, timeRange)
+		if err != nil {
+			return nil, err
+		}
+		tracksIds = c.storePlaylistTracks(playlistTracks)
+		c.SourceToTracksMap[source] = tracksIds
+	}
+	return c.playlistTracks(tracksIds), nil
+}
+
+// GetTopArtistsTracks returns the top tracks of each of the user's most
+// listened artists over the time range
+func (c *SpotifyCache) GetTopArtistsTracks(ctx context.Context, s spotify_api.SongProvider, accessToken, timeRange string) ([]spotify_api.PlaylistTrack, error) {
+	source := "top-artists:" + timeRange
+	tracksIds, exist := c.SourceToTracksMap[source]
+	if !exist {
+		artists, err := s.FetchTopArtists(ctx, accessToken, timeRange)
+		if err != nil {
+			return nil, err
+		}
+
+		tracksIds = make([]string, 0)
+		for _, artist := range artists {
+			c.ArtistMap[artist.Id] = artist
+			err := c.cacheTopTracksAlbum(ctx, s, accessToken, artist.Id)
+			if err != nil {
+				return nil, err
+			}
+
+			albumId := c.ArtistToAlbumsMap[artist.Id][0]
+			for _, trackId := range c.AlbumToTracksMap[albumId] {
+				if _, ok := c.TrackIdToAlbumId[trackId]; !ok {
+					c.TrackIdToAlbumId[trackId] = albumId
+				}
+				if _, ok := c.TrackIdToArtistId[trackId]; !ok {
+					c.TrackIdToArtistId[trackId] = artist.Id
+				}
+				tracksIds = append(tracksIds, trackId)
+			}
+		}
+		c.SourceToTracksMap[source] = tracksIds
+	}
+	return c.playlistTracks(tracksIds), nil
+}
+
+// storePlaylistTracks caches tracks together with their real album and
+// primary artist and returns their ids
+func (c *SpotifyCache) storePlaylistTracks(playlistTracks []spotify_api.PlaylistTrack) []string {
+	tracksIds := make([]string, 0, len(playlistTracks))
+	for _, playlistTrack := range playlistTracks {
+		track := playlistTrack.Track
+		c.TrackMap[track.ID] = track
+		c.TrackIdToAlbumId[track.ID] = playlistTrack.Album.ID
+		c.TrackIdToArtistId[track.ID] = playlistTrack.Artist.Id
+		tracksIds = append(tracksIds, track.ID)
+
+		// keep richer entries fetched through the artist search
+		if _, ok := c.AlbumMap[playlistTrack.Album.ID]; !ok {
+			c.AlbumMap[playlistTrack.Album.ID] = playlistTrack.Album
+		}
+		if _, ok := c.ArtistMap[playlistTrack.Artist.Id]; !ok {
+			c.ArtistMap[playlistTrack.Artist.Id] = playlistTrack.Artist
+		}
+		if _, ok := c.AlbumIdToArtistId[playlistTrack.Album.ID]; !ok {
+			c.AlbumIdToArtistId[playlistTrack.Album.ID] = playlistTrack.Artist.Id
+		}
+	}
+	return tracksIds
+}
+
+// playlistTracks rebuilds cached tracks with their album and artist
+func (c *SpotifyCache) playlistTracks(tracksIds []string) []spotify_api.PlaylistTrack {
+	playlistTracks := make([]spotify_api.PlaylistTrack, 0, len(tracksIds))
+	for _, trackId := range tracksIds {
+		albumId := c.TrackIdToAlbumId[trackId]
+		artistId := c.TrackIdToArtistId[trackId]
+		playlistTracks = append(playlistTracks, spotify_api.PlaylistTrack{
+			Track:  c.TrackMap[trackId],
+			Album:  c.AlbumMap[albumId],
+			Artist: c.ArtistMap[artistId],
+		})
+	}
+	return playlistTracks
+}
@@ -0,0 +1,207 @@
+package game
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Fields of an answer, in the order they are shown and matched
+const (
+	FieldTitle    = "title"
+	FieldArtist   = "artist"
+	FieldFeatured = "featured"
+	FieldAlbum    = "album"
+	FieldYear     = "year"
+)
+
+// FieldPoints are the default points for naming each field, the title's
+// are scaled in Heardle mode
+var FieldPoints = map[string]int{
+	FieldTitle:    100,
+	FieldArtist:   50,
+	FieldFeatured: 25,
+	FieldAlbum:    50,
+	FieldYear:     25,
+}
+
+// OptionalFields are the fields that can be asked besides the title
+var OptionalFields = []string{FieldArtist, FieldFeatured, FieldAlbum, FieldYear}
+
+// Answer is what the player may name about a song, the empty parts aren't
+// asked
+type Answer struct {
+	Title      string
+	Artist     string
+	Featured   []string
+	Album      string
+	Year       string
+	AlbumImage string
+}
+
+// AnswerField is a part of the answer guessed and scored on its own
+type AnswerField struct {
+	Name   string
+	Words  *TitleGuessState
+	points int
+	// given fields were shown by a hint, they don't score
+	given bool
+}
+
+// Guessed tells whether every word of the field was found
+func (f *AnswerField) Guessed() bool {
+	return len(f.Words.AliveWords()) == 0
+}
+
+// Points are what naming the field earned
+func (f *AnswerField) Points() int {
+	return f.points
+}
+
+// SetAnswer starts guessing a song. The featured artists are read from the
+// title when they aren't given, and the artist is only asked when it is
+// hidden. Only the Asked fields are guessed besides the title
+func (g *GuessState) SetAnswer(answer Answer) {
+	if len(answer.Featured) == 0 {
+		answer.Featured = FeaturedArtists(answer.Title)
+	}
+
+	g.Fields = nil
+	for _, field := range []struct {
+		name string
+		text string
+	}{
+		{FieldTitle, answer.Title},
+		{FieldArtist, answer.Artist},
+		{FieldFeatured, strings.Join(answer.Featured, ", ")},
+		{FieldAlbum, answer.Album},
+		{FieldYear, ReleaseYear(answer.Year)},
+	} {
+		if field.name == FieldArtist && g.ShowArtist {
+			continue
+		}
+		if field.name != FieldTitle && !slices.Contains(g.Asked, field.name) {
+			continue
+		}
+		words := newTitleGuessState(field.text, g.Matcher, g.Pipeline)
+		if field.name != FieldTitle && len(words.Tokens) == 0 {
+			continue
+		}
+		g.Fields = append(g.Fields, &AnswerField{Name: field.name, Words: words})
+	}
+
+	g.Title = g.Fields[0].Words
+	g.Artist = answer.Artist
+	g.AlbumImage = answer.AlbumImage
+	g.State = ""
+	g.revealed = false
+	g.unlocks = 0
+	g.closeWords = nil
+	g.choices = nil
+	g.ruledOut = nil
+	g.hints = nil
+	g.started = time.Now()
+	g.round = RoundScore{}
+	g.lifeLost = false
+	g.timedOut = false
+}
+
+// Field is the field of the current answer with that name
+func (g *GuessState) Field(name string) (*AnswerField, bool) {
+	for _, field := range g.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+// ShowField is the field as the player may see it
+func (g *GuessState) ShowField(name string) string {
+	field, ok := g.Field(name)
+	if !ok {
+		return ""
+	}
+	if g.AnswerShown() {
+		return field.Words.RealTitle
+	}
+	return field.Words.ShowGuessState()
+}
+
+// ArtistShown tells whether the artist can be shown to the player
+func (g *GuessState) ArtistShown() bool {
+	return g.ShowArtist || g.AnswerShown() || g.fieldGuessed(FieldArtist)
+}
+
+// CoverShown tells whether the album cover can be shown to the player
+func (g *GuessState) CoverShown() bool {
+	return g.ShowArtist || g.AnswerShown() || g.fieldGuessed(FieldAlbum) || slices.Contains(g.hints, HintCover)
+}
+
+func (g *GuessState) fieldGuessed(name string) bool {
+	field, ok := g.Field(name)
+	return ok && field.Guessed()
+}
+
+// AnswerWord tells whether the guessed word names a word of any field of
+// the answer, found or not
+func (g *GuessState) AnswerWord(word string) bool {
+	for _, field := range g.Fields {
+		for _, token := range field.Words.Tokens {
+			if field.Words.Matcher.Match(word, token.Word) >= MatchFuzzy {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bestMatch finds the word of any field left to guess that the guessed
+// word matches best, ties go to the first field
+func (g *GuessState) bestMatch(guess string) (*AnswerField, int, Match) {
+	var best *AnswerField
+	bestIndex, bestMatch := -1, MatchNone
+	for _, field := range g.Fields {
+		i, match := field.Words.bestMatch(guess)
+		if match > bestMatch {
+			best, bestIndex, bestMatch = field, i, match
+		}
+	}
+	return best, bestIndex, bestMatch
+}
+
+// FeaturedArtists reads the artists after "feat." or "ft." in a title, e.g.
+// "Stay (feat. Justin Bieber & Kid LAROI)"
+func FeaturedArtists(title string) []string {
+	for _, span := range splitFields(title) {
+		w := strings.ToLower(strings.TrimLeft(title[span.Start:span.End], "(["))
+		if span.Start == 0 || !isFeaturing(w) {
+			continue
+		}
+
+		rest := title[span.End:]
+		if end := strings.IndexAny(rest, ")]"); end >= 0 {
+			rest = rest[:end]
+		}
+		rest = strings.NewReplacer("&", ",", " and ", ",", " x ", ",", " with ", ",").Replace(rest)
+
+		var artists []string
+		for _, artist := range strings.Split(rest, ",") {
+			if artist = strings.TrimSpace(artist); artist != "" {
+				artists = append(artists, artist)
+			}
+		}
+		return artists
+	}
+	return nil
+}
+
+// ReleaseYear is the year of a release date such as 1975-10-31, empty when
+// there is none
+func ReleaseYear(date string) string {
+	if len(date) < 4 || strings.IndexFunc(date[:4], func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+		return ""
+	}
+	return date[:4]
+}
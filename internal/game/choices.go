@@ -0,0 +1,41 @@
+package game
+
+// SetChoices offers titles to pick from instead of typing the title, one of
+// them must be the title
+func (g *GuessState) SetChoices(choices []string) {
+	g.choices = choices
+	g.ruledOut = make([]bool, len(choices))
+}
+
+// Choices are the titles offered for the current song, none when the title
+// is typed
+func (g *GuessState) Choices() []string {
+	return g.choices
+}
+
+// RuledOut tells whether the choice was already picked and wrong
+func (g *GuessState) RuledOut(i int) bool {
+	return i >= 0 && i < len(g.ruledOut) && g.ruledOut[i]
+}
+
+// Choose picks one of the titles offered. The right one names the whole
+// title and scores like a typed guess, a wrong one counts as a wrong guess
+func (g *GuessState) Choose(i int) bool {
+	if g.revealed || g.Guessed() || i < 0 || i >= len(g.choices) || g.ruledOut[i] {
+		return false
+	}
+
+	if g.choices[i] != g.Title.RealTitle {
+		g.ruledOut[i] = true
+		g.closeWords = nil
+		g.settle(0)
+		g.State = "Wrong choice"
+		return false
+	}
+
+	for j := range g.Title.found {
+		g.Title.found[j] = true
+	}
+	g.closeWords = nil
+	return g.settle(len(g.Title.Tokens))
+}
@@ -0,0 +1,51 @@
+package game
+
+import "time"
+
+// HeardleSteps are the song lengths unlocked one after the other in the
+// progressive unlock mode, after each wrong guess or skip
+var HeardleSteps = []time.Duration{
+	1 * time.Second,
+	2 * time.Second,
+	4 * time.Second,
+	7 * time.Second,
+	11 * time.Second,
+	16 * time.Second,
+}
+
+// HeardlePoints are the points for naming the song after that many unlocks
+var HeardlePoints = []int{100, 80, 60, 40, 25, 10}
+
+// SetHeardle turns the progressive unlock mode on or off
+func (g *GuessState) SetHeardle(heardle bool) {
+	g.Heardle = heardle
+	g.unlocks = 0
+}
+
+// Unlock lets the player hear the next step of the song. It is false when
+// the mode is off or the whole length is already unlocked
+func (g *GuessState) Unlock() bool {
+	if !g.Heardle || g.unlocks+1 >= len(HeardleSteps) {
+		return false
+	}
+	g.unlocks++
+	return true
+}
+
+// Unlocks is how many times the song was unlocked further
+func (g *GuessState) Unlocks() int {
+	return g.unlocks
+}
+
+// UnlockedLength is how much of the song the player may hear
+func (g *GuessState) UnlockedLength() time.Duration {
+	return HeardleSteps[g.unlocks]
+}
+
+// guessPoints are the points of the title before time and streak
+func (g *GuessState) guessPoints() int {
+	if g.Heardle {
+		return HeardlePoints[g.unlocks]
+	}
+	return g.Scoring.fieldPoints(FieldTitle)
+}
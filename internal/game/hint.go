@@ -0,0 +1,131 @@
+package game
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Hints the player can ask for, in the order they are offered
+const (
+	HintLetter       = "letter"
+	HintFirstLetters = "first-letters"
+	HintArtist       = "artist"
+	HintCover        = "cover"
+)
+
+// Hints are the hints offered, cheapest first
+var Hints = []string{HintLetter, HintFirstLetters, HintArtist, HintCover}
+
+// DefaultHintCosts are the points each hint takes from the score
+var DefaultHintCosts = map[string]int{
+	HintLetter:       10,
+	HintFirstLetters: 25,
+	HintArtist:       25,
+	HintCover:        15,
+}
+
+// HintAvailable tells whether the hint is allowed and would show anything
+// new about the current song
+func (g *GuessState) HintAvailable(hint string) bool {
+	if _, ok := g.HintCosts[hint]; !ok || g.AnswerShown() {
+		return false
+	}
+
+	switch hint {
+	case HintLetter:
+		for i := range g.Title.Tokens {
+			if !g.Title.found[i] && g.Title.nextLetter(i) > 0 {
+				return true
+			}
+		}
+	case HintFirstLetters:
+		for i := range g.Title.Tokens {
+			if !g.Title.found[i] && g.Title.shown[i] == 0 && g.Title.nextLetter(i) > 0 {
+				return true
+			}
+		}
+	case HintArtist:
+		return !g.ArtistShown()
+	case HintCover:
+		return !g.CoverShown()
+	}
+	return false
+}
+
+// Hint shows a letter of the title, the first letter of each of its words,
+// the artist or the album cover, and takes its cost from the score. It is
+// false when the hint has nothing to show
+func (g *GuessState) Hint(hint string) bool {
+	if !g.HintAvailable(hint) {
+		return false
+	}
+
+	switch hint {
+	case HintLetter:
+		// the next letter of the first word not fully shown
+		for i := range g.Title.Tokens {
+			if !g.Title.found[i] && g.Title.showLetter(i) {
+				break
+			}
+		}
+	case HintFirstLetters:
+		for i := range g.Title.Tokens {
+			if !g.Title.found[i] && g.Title.shown[i] == 0 {
+				g.Title.showLetter(i)
+			}
+		}
+	case HintArtist:
+		// the artist is given, naming it doesn't score anymore
+		if field, ok := g.Field(FieldArtist); ok {
+			field.given = true
+			for i := range field.Words.found {
+				field.Words.found[i] = true
+			}
+		}
+	}
+
+	g.hints = append(g.hints, hint)
+	g.round.Hints -= g.HintCosts[hint]
+	g.points -= g.HintCosts[hint]
+	return true
+}
+
+// HintsUsed are the hints used on the current song
+func (g *GuessState) HintsUsed() []string {
+	return g.hints
+}
+
+// HintPoints are the points the hints on the current song took
+func (g *GuessState) HintPoints() int {
+	return -g.round.Hints
+}
+
+// nextLetter is how many bytes of the i-th word showing its next letter
+// takes, with the marks after it, 0 when every letter is shown
+func (t *TitleGuessState) nextLetter(i int) int {
+	token := t.Tokens[i]
+	rest := t.RealTitle[token.Start+t.shown[i] : token.End]
+	for j, r := range rest {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			continue
+		}
+		end := j + utf8.RuneLen(r)
+		for end < len(rest) {
+			mark, size := utf8.DecodeRuneInString(rest[end:])
+			if !unicode.IsMark(mark) {
+				break
+			}
+			end += size
+		}
+		return end
+	}
+	return 0
+}
+
+// showLetter shows the next letter of the i-th word, false when every
+// letter is shown
+func (t *TitleGuessState) showLetter(i int) bool {
+	n := t.nextLetter(i)
+	t.shown[i] += n
+	return n > 0
+}
@@ -0,0 +1,118 @@
+package game
+
+// language holds what the normalisation rules need to know of a language
+type language struct {
+	and string
+	// numbers spelled as one word, tens are the numbers other units add to
+	numbers map[string]int
+	tens    map[string]int
+	// stopWords are dropped from titles and guesses, only the articles
+	stopWords []string
+	// contractions are whole words written with an apostrophe or shortened
+	contractions map[string]string
+	// elisions are the article or pronoun glued to the next word, e.g. l'
+	elisions []string
+	// droppedG spells "lovin'" as "loving"
+	droppedG bool
+}
+
+// spelledNumbers maps the words to start, start+step, start+2*step...
+func spelledNumbers(start, step int, words ...string) map[string]int {
+	numbers := make(map[string]int, len(words))
+	for i, w := range words {
+		numbers[w] = start + i*step
+	}
+	return numbers
+}
+
+var (
+	english = language{
+		and: "and",
+		numbers: spelledNumbers(0, 1, "zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+			"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"),
+		tens:      spelledNumbers(20, 10, "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"),
+		stopWords: []string{"the", "a", "an"},
+		contractions: map[string]string{
+			"'n'":    "and",
+			"'n":     "and",
+			"n'":     "and",
+			"n":      "and",
+			"'til":   "until",
+			"'cause": "because",
+			"'em":    "them",
+			"o'":     "of",
+		},
+		droppedG: true,
+	}
+
+	spanish = language{
+		and: "y",
+		numbers: spelledNumbers(0, 1, "cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve", "diez",
+			"once", "doce", "trece", "catorce", "quince", "dieciseis", "diecisiete", "dieciocho", "diecinueve"),
+		tens:      spelledNumbers(20, 10, "veinte", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"),
+		stopWords: []string{"el", "la", "los", "las", "un", "una", "unos", "unas"},
+		contractions: map[string]string{
+			"pa'": "para",
+		},
+	}
+
+	french = language{
+		and: "et",
+		numbers: spelledNumbers(0, 1, "zero", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf", "dix",
+			"onze", "douze", "treize", "quatorze", "quinze", "seize"),
+		tens:      spelledNumbers(20, 10, "vingt", "trente", "quarante", "cinquante", "soixante"),
+		stopWords: []string{"le", "la", "les", "l", "une", "des"},
+		elisions:  []string{"l'", "d'", "j'", "m'", "n'", "s'", "t'", "c'", "qu'"},
+	}
+
+	german = language{
+		and: "und",
+		numbers: spelledNumbers(0, 1, "null", "eins", "zwei", "drei", "vier", "funf", "sechs", "sieben", "acht", "neun", "zehn",
+			"elf", "zwolf"),
+		tens:      spelledNumbers(20, 10, "zwanzig", "dreißig", "vierzig", "funfzig", "sechzig", "siebzig", "achtzig", "neunzig"),
+		stopWords: []string{"der", "die", "das", "ein", "eine"},
+	}
+)
+
+// newPipeline is the rule set of a language: the version and featured
+// artists are cut, then the words are spelled the same way
+func newPipeline(code string, lang language) Pipeline {
+	return Pipeline{
+		Language: code,
+		Rules: []Rule{
+			bracketsRule(),
+			dashSuffixRule(),
+			featuringRule(),
+			ampersandRule(lang.and),
+			contractionRule(lang),
+			transliterateRule(),
+			symbolsRule(),
+			accentsRule(),
+			romanRule(),
+			numberWordRule(lang),
+			stopWordRule(lang),
+		},
+	}
+}
+
+// English is the default normalisation
+var English = newPipeline("en", english)
+
+// Pipelines are the rule sets of each language the titles can be in
+var Pipelines = map[string]Pipeline{
+	"en": English,
+	"es": newPipeline("es", spanish),
+	"fr": newPipeline("fr", french),
+	"de": newPipeline("de", german),
+}
+
+// Languages lists the language codes of Pipelines in the order to offer them
+var Languages = []string{"en", "es", "fr", "de"}
+
+// PipelineFor is the rule set of the language, English when it is unknown
+func PipelineFor(code string) Pipeline {
+	if p, ok := Pipelines[code]; ok {
+		return p
+	}
+	return English
+}
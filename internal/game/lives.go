@@ -0,0 +1,64 @@
+package game
+
+import "time"
+
+// SetLives starts the survival mode with that many lives, 0 turns it off
+func (g *GuessState) SetLives(lives int) {
+	g.lives = lives
+	g.survival = lives > 0
+	g.survived = 0
+}
+
+// Survival tells whether the game is played with lives
+func (g *GuessState) Survival() bool {
+	return g.survival
+}
+
+// Lives are the lives left in survival mode
+func (g *GuessState) Lives() int {
+	return g.lives
+}
+
+// Survived is how many songs were got through with lives left
+func (g *GuessState) Survived() int {
+	return g.survived
+}
+
+// LoseLife takes a life for the current song, each song costs one at most.
+// It is false when no life was taken
+func (g *GuessState) LoseLife() bool {
+	if !g.survival || g.lifeLost || g.lives == 0 {
+		return false
+	}
+	g.lifeLost = true
+	g.lives--
+	return true
+}
+
+// Deadline is when the current song must be named by in survival mode, the
+// zero time when there is none
+func (g *GuessState) Deadline() time.Time {
+	if !g.survival || g.RoundTime <= 0 {
+		return time.Time{}
+	}
+	return g.started.Add(g.RoundTime)
+}
+
+// Timeout ends the current song once its deadline is over, which costs a
+// life. It is false when there is still time or the song is over
+func (g *GuessState) Timeout() bool {
+	deadline := g.Deadline()
+	if deadline.IsZero() || g.AnswerShown() || time.Now().Before(deadline) {
+		return false
+	}
+	g.LoseLife()
+	g.Reveal()
+	g.State = "Time's up"
+	g.timedOut = true
+	return true
+}
+
+// TimedOut tells whether the current song ran out of time
+func (g *GuessState) TimedOut() bool {
+	return g.timedOut
+}
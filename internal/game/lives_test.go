@@ -0,0 +1,91 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FerNunez/NameThatSong/internal/game"
+)
+
+func TestLives(t *testing.T) {
+	testCases := []struct {
+		name          string
+		actions       []string
+		expectedLives int
+		survived      int
+	}{
+		{"named songs keep lives", []string{"guess", "guess"}, 3, 2},
+		{"skip costs a life", []string{"skip"}, 2, 1},
+		{"a life per song at most", []string{"lose", "skip"}, 2, 1},
+		{"reveal then skip", []string{"reveal", "skip"}, 2, 1},
+		{"last life", []string{"skip", "skip", "skip"}, 0, 2},
+		{"no lives below zero", []string{"skip", "skip", "skip", "skip"}, 0, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.SetLives(3)
+			g.SetTitle("Hey Jude", "The Beatles", "cover.jpg")
+			for _, action := range tc.actions {
+				switch action {
+				case "lose":
+					g.LoseLife()
+				case "reveal":
+					g.Reveal()
+				case "guess", "skip":
+					// both move on to the next song
+					if action == "guess" {
+						g.Guess("hey jude")
+					}
+					g.Skip()
+					g.SetTitle("Hey Jude", "The Beatles", "cover.jpg")
+				}
+			}
+
+			if g.Lives() != tc.expectedLives {
+				t.Errorf("Lives() = %d, want %d", g.Lives(), tc.expectedLives)
+			}
+			if g.Survived() != tc.survived {
+				t.Errorf("Survived() = %d, want %d", g.Survived(), tc.survived)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lives    int
+		elapsed  time.Duration
+		expected bool
+	}{
+		{"in time", 3, 10 * time.Second, false},
+		{"too late", 3, 31 * time.Second, true},
+		{"no deadline without lives", 0, time.Minute, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.SetLives(tc.lives)
+			g.RoundTime = 30 * time.Second
+			g.SetTitle("Hey Jude", "The Beatles", "cover.jpg")
+			g.StartTimer(time.Now().Add(-tc.elapsed))
+
+			if got := g.Timeout(); got != tc.expected {
+				t.Errorf("Timeout() = %v, want %v", got, tc.expected)
+			}
+			if tc.expected && (g.Lives() != tc.lives-1 || !g.AnswerShown()) {
+				t.Errorf("timed out song: lives %d, answer shown %v", g.Lives(), g.AnswerShown())
+			}
+			if g.TimedOut() != tc.expected {
+				t.Errorf("TimedOut() = %v, want %v", g.TimedOut(), tc.expected)
+			}
+			g.SetTitle("Let It Be", "The Beatles", "cover.jpg")
+			if g.TimedOut() {
+				t.Errorf("TimedOut() after the next song = true, want false")
+			}
+		})
+	}
+}
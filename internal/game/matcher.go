@@ -0,0 +1,177 @@
+package game
+
+// Match is how well a guessed word matches a word of the title
+type Match int
+
+const (
+	MatchNone Match = iota
+	// MatchClose is not accepted but shown to the player as nearly right
+	MatchClose
+	// MatchFuzzy is accepted with a few typos or because it sounds the same
+	MatchFuzzy
+	MatchExact
+)
+
+// Matcher decides whether a guessed word names a word of the title. The
+// tolerances are the share of the title word's letters that may be wrong,
+// so long words forgive more typos than short ones
+type Matcher struct {
+	// Tolerance accepts a word within that many edits per letter, 0 only
+	// accepts the exact word
+	Tolerance float64
+	// CloseTolerance reports a word within that many edits per letter as close
+	CloseTolerance float64
+	// Phonetic also accepts words that sound the same, e.g. "nite" for "night"
+	Phonetic bool
+}
+
+// DefaultMatcher forgives one typo every five letters
+var DefaultMatcher = Matcher{
+	Tolerance:      0.2,
+	CloseTolerance: 0.4,
+}
+
+// ExactMatcher only accepts the words as they are written
+var ExactMatcher = Matcher{}
+
+// LenientMatcher forgives a typo every three letters and words that sound
+// the same
+var LenientMatcher = Matcher{
+	Tolerance:      0.34,
+	CloseTolerance: 0.5,
+	Phonetic:       true,
+}
+
+// Strictness levels a game can be played with, from the most forgiving
+const (
+	StrictnessLenient = "lenient"
+	StrictnessNormal  = "normal"
+	StrictnessExact   = "exact"
+)
+
+// Strictnesses are the matchers of each strictness level
+var Strictnesses = map[string]Matcher{
+	StrictnessLenient: LenientMatcher,
+	StrictnessNormal:  DefaultMatcher,
+	StrictnessExact:   ExactMatcher,
+}
+
+// minPhoneticLength keeps short words, which too easily sound alike, exact
+const minPhoneticLength = 4
+
+// Match compares a guessed word to a title word, both cleaned
+func (m Matcher) Match(guess, word string) Match {
+	if guess == word {
+		return MatchExact
+	}
+
+	g, w := []rune(guess), []rune(word)
+	distance := editDistance(g, w)
+	if distance <= int(float64(len(w))*m.Tolerance) {
+		return MatchFuzzy
+	}
+	if m.Phonetic && len(w) >= minPhoneticLength && len(g) >= minPhoneticLength &&
+		phoneticKey(guess) == phoneticKey(word) {
+		return MatchFuzzy
+	}
+	if distance <= int(float64(len(w))*m.CloseTolerance) {
+		return MatchClose
+	}
+	return MatchNone
+}
+
+// editDistance is the number of inserted, deleted, substituted or swapped
+// neighbour letters to turn a into b (optimal string alignment)
+func editDistance(a, b []rune) int {
+	// three rows are enough: the swap looks two letters back
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
+
+// phoneticKey roughly spells a word the way it sounds: common English
+// spellings of the same sound are merged, silent letters dropped and only
+// the first vowel kept, so "night" and "nite" or "phone" and "fone" share
+// a key
+func phoneticKey(word string) string {
+	r := []rune(word)
+	if len(r) > 2 && r[len(r)-1] == 'e' {
+		r = r[:len(r)-1]
+	}
+
+	sounds := make([]rune, 0, len(r))
+	for i := 0; i < len(r); i++ {
+		next := rune(0)
+		if i+1 < len(r) {
+			next = r[i+1]
+		}
+
+		switch {
+		case r[i] == 'p' && next == 'h':
+			sounds = append(sounds, 'f')
+			i++
+		case r[i] == 'g' && next == 'h':
+			i++
+		case i == 0 && (r[i] == 'k' || r[i] == 'w') && (next == 'n' || next == 'r'):
+			// silent k of knife and w of write
+		case r[i] == 'c' && (next == 'e' || next == 'i' || next == 'y'):
+			sounds = append(sounds, 's')
+		case r[i] == 'c' || r[i] == 'q':
+			sounds = append(sounds, 'k')
+			if next == 'k' {
+				i++
+			}
+		case r[i] == 'x':
+			sounds = append(sounds, 'k', 's')
+		case r[i] == 'z':
+			sounds = append(sounds, 's')
+		default:
+			sounds = append(sounds, r[i])
+		}
+	}
+
+	key := make([]rune, 0, len(sounds))
+	vowelKept := false
+	for i, s := range sounds {
+		if i > 0 && s == sounds[i-1] {
+			continue
+		}
+		if isVowel(s) {
+			if vowelKept {
+				continue
+			}
+			vowelKept = true
+		} else if i > 0 && (s == 'h' || s == 'w' || s == 'y') {
+			continue
+		}
+		key = append(key, s)
+	}
+	return string(key)
+}
+
+func isVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package game_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/game"
+)
+
+func TestMatcherMatch(t *testing.T) {
+	phonetic := game.DefaultMatcher
+	phonetic.Phonetic = true
+
+	testCases := []struct {
+		name     string
+		matcher  game.Matcher
+		guess    string
+		word     string
+		expected game.Match
+	}{
+		{"exact", game.DefaultMatcher, "bohemian", "bohemian", game.MatchExact},
+		{"swapped letters", game.DefaultMatcher, "bohemain", "bohemian", game.MatchFuzzy},
+		{"missing letter", game.DefaultMatcher, "rhapsdy", "rhapsody", game.MatchFuzzy},
+		{"extra letter", game.DefaultMatcher, "rhapssody", "rhapsody", game.MatchFuzzy},
+		{"two typos in a long word", game.DefaultMatcher, "extrordinery", "extraordinary", game.MatchFuzzy},
+		{"two typos in a short word", game.DefaultMatcher, "kween", "queen", game.MatchClose},
+		{"short word stays exact", game.DefaultMatcher, "lvoe", "love", game.MatchClose},
+		{"one letter word", game.DefaultMatcher, "b", "a", game.MatchNone},
+		{"different word", game.DefaultMatcher, "yellow", "bohemian", game.MatchNone},
+		{"exact matcher", game.ExactMatcher, "bohemain", "bohemian", game.MatchNone},
+		{"exact matcher exact word", game.ExactMatcher, "bohemian", "bohemian", game.MatchExact},
+		{"sounds alike off", game.DefaultMatcher, "nite", "night", game.MatchNone},
+		{"sounds alike", phonetic, "nite", "night", game.MatchFuzzy},
+		{"ph sounds f", phonetic, "fone", "phone", game.MatchFuzzy},
+		{"c sounds k", phonetic, "kool", "cool", game.MatchFuzzy},
+		{"different vowel", phonetic, "lite", "lout", game.MatchNone},
+		{"too short to sound alike", phonetic, "luv", "love", game.MatchNone},
+		{"lenient short word", game.LenientMatcher, "lvoe", "love", game.MatchFuzzy},
+		{"lenient sounds alike", game.LenientMatcher, "nite", "night", game.MatchFuzzy},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.matcher.Match(tc.guess, tc.word)
+			if got != tc.expected {
+				t.Errorf("Match(%q, %q) = %v, want %v", tc.guess, tc.word, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFuzzyGuess(t *testing.T) {
+	testCases := []struct {
+		name            string
+		title           string
+		matcher         game.Matcher
+		guesses         []string
+		expectedCorrect bool
+		expectedClose   []string
+	}{
+		{"typo accepted", "Bohemian Rhapsody", game.DefaultMatcher, []string{"bohemain rhapsody"}, true, nil},
+		{"typo rejected when exact", "Bohemian Rhapsody", game.ExactMatcher, []string{"bohemain rhapsody"}, false, nil},
+		{"close word reported", "Bohemian Rhapsody", game.DefaultMatcher, []string{"bohmaen rhapsody"}, false, []string{"bohmaen"}},
+		{"close words cleared by the next guess", "Bohemian Rhapsody", game.DefaultMatcher, []string{"bohmaen", "rhapsody"}, false, nil},
+		{"repeated word guessed twice", "New York, New York", game.DefaultMatcher, []string{"new york", "new york"}, true, nil},
+		{"single word", "Yellow", game.DefaultMatcher, []string{"yelow"}, true, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.Matcher = tc.matcher
+			g.SetTitle(tc.title, "Artist", "cover.jpg")
+
+			correct := false
+			for _, guess := range tc.guesses {
+				_, correct = g.Guess(guess)
+			}
+
+			if correct != tc.expectedCorrect {
+				t.Errorf("Guess() correct = %v, want %v", correct, tc.expectedCorrect)
+			}
+			if !slices.Equal(g.CloseWords(), tc.expectedClose) {
+				t.Errorf("CloseWords() = %q, want %q", g.CloseWords(), tc.expectedClose)
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package game
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names of the normalisation rules
+const (
+	RuleBrackets    = "brackets"
+	RuleDashSuffix  = "dash-suffix"
+	RuleFeaturing   = "featuring"
+	RuleAmpersand   = "ampersand"
+	RuleContraction = "contraction"
+	RuleSymbols     = "symbols"
+	RuleAccents     = "accents"
+	RuleRoman       = "roman-numeral"
+	RuleNumberWord  = "number-word"
+	RuleStopWord    = "stop-word"
+)
+
+// Token is a normalised word of a text and the span of the text it is
+// written in, from byte Start to End
+type Token struct {
+	Word  string
+	Start int
+	End   int
+}
+
+// Rule is a named step of a normalisation Pipeline. Cut rules find where
+// the part of a title that doesn't need to be guessed starts, -1 when there
+// is none. Text rules rewrite each lowercase word as written, it may become
+// several words. Words rules rewrite the words of the whole text
+type Rule struct {
+	Name  string
+	Cut   func(string) int
+	Text  func(string) string
+	Words func([]Token) []Token
+}
+
+// Pipeline turns a title or a guess into the words that are compared, so
+// both must go through the same pipeline. The text is cut where its
+// optional part starts, split in words on symbols and spaces, and each
+// word goes through the Text rules in order then all through the Words rules
+type Pipeline struct {
+	Language string
+	Rules    []Rule
+}
+
+// Required is the length of the part of the text that must be guessed,
+// what follows is a version or the featured artists
+func (p Pipeline) Required(s string) int {
+	end := len(s)
+	for _, rule := range p.Rules {
+		if rule.Cut == nil {
+			continue
+		}
+		if i := rule.Cut(s[:end]); i >= 0 {
+			end = i
+		}
+	}
+	return end
+}
+
+// Tokens normalises the required part of the text
+func (p Pipeline) Tokens(s string) []Token {
+	s = s[:p.Required(s)]
+
+	var tokens []Token
+	for _, span := range splitWords(s) {
+		text := strings.ToLower(s[span.Start:span.End])
+		for _, rule := range p.Rules {
+			if rule.Text != nil {
+				text = rule.Text(text)
+			}
+		}
+		for _, w := range strings.Fields(text) {
+			tokens = append(tokens, Token{Word: w, Start: span.Start, End: span.End})
+		}
+	}
+
+	for _, rule := range p.Rules {
+		if rule.Words != nil {
+			tokens = rule.Words(tokens)
+		}
+	}
+	return tokens
+}
+
+// Words normalises the text
+func (p Pipeline) Words(s string) []string {
+	tokens := p.Tokens(s)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Word
+	}
+	return words
+}
+
+// Mask hides the letters of every word of the title that is still alive,
+// everything else is shown as it is written
+func (p Pipeline) Mask(original string, aliveWords map[string]uint8) string {
+	var hidden []Token
+	for _, token := range p.Tokens(original) {
+		if _, ok := aliveWords[token.Word]; ok {
+			hidden = append(hidden, token)
+		}
+	}
+	return maskSpans(original, hidden)
+}
+
+// maskSpans replaces each letter and number written in the tokens by an
+// underscore, the accents of masked letters are dropped
+func maskSpans(original string, tokens []Token) string {
+	if len(tokens) == 0 {
+		return original
+	}
+
+	var solution strings.Builder
+	solution.Grow(len(original))
+	for i, r := range original {
+		masked := slices.ContainsFunc(tokens, func(t Token) bool { return i >= t.Start && i < t.End })
+		switch {
+		case !masked:
+			solution.WriteRune(r)
+		case unicode.IsMark(r):
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			solution.WriteByte('_')
+		default:
+			solution.WriteRune(r)
+		}
+	}
+	return solution.String()
+}
+
+// splitWords finds the words written in the text: letters, numbers and
+// apostrophes, "&" on its own, and each Chinese character
+func splitWords(s string) []Token {
+	var spans []Token
+	start := -1
+	for i, r := range s {
+		switch {
+		case isHan(r) || r == '&':
+			if start >= 0 {
+				spans = append(spans, Token{Start: start, End: i})
+				start = -1
+			}
+			spans = append(spans, Token{Start: i, End: i + utf8.RuneLen(r)})
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) || r == '\'' || r == '’':
+			if start < 0 {
+				start = i
+			}
+		default:
+			if start >= 0 {
+				spans = append(spans, Token{Start: start, End: i})
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, Token{Start: start, End: len(s)})
+	}
+	return spans
+}
+
+// bracketsRule leaves the "(Remastered 2011)" or "[Live]" part of a title
+// optional, unless the title starts with it
+func bracketsRule() Rule {
+	return Rule{
+		Name: RuleBrackets,
+		Cut: func(s string) int {
+			cut := -1
+			for _, brackets := range []string{"()", "[]"} {
+				i := strings.Index(s, brackets[:1])
+				if i < 0 || strings.TrimSpace(s[:i]) == "" || !strings.Contains(s[i:], brackets[1:]) {
+					continue
+				}
+				if cut < 0 || i < cut {
+					cut = i
+				}
+			}
+			return cut
+		},
+	}
+}
+
+// dashSuffixRule leaves the " - Radio Edit" part of a title optional. Only
+// a spaced dash starts it, "Anti-Hero" stays whole
+func dashSuffixRule() Rule {
+	return Rule{
+		Name: RuleDashSuffix,
+		Cut: func(s string) int {
+			cut := -1
+			for _, dash := range []string{" - ", " – ", " — "} {
+				i := strings.Index(s, dash)
+				if i < 0 || strings.TrimSpace(s[:i]) == "" {
+					continue
+				}
+				if cut < 0 || i < cut {
+					cut = i
+				}
+			}
+			return cut
+		},
+	}
+}
+
+// featuringRule leaves the featured artists after "feat." or "ft." optional
+func featuringRule() Rule {
+	return Rule{
+		Name: RuleFeaturing,
+		Cut: func(s string) int {
+			for _, span := range splitFields(s) {
+				w := strings.ToLower(s[span.Start:span.End])
+				if span.Start > 0 && isFeaturing(w) {
+					return span.Start
+				}
+			}
+			return -1
+		},
+	}
+}
+
+func isFeaturing(w string) bool {
+	return slices.Contains([]string{"feat", "feat.", "ft", "ft.", "featuring"}, w)
+}
+
+// splitFields finds the words of the text separated by spaces
+func splitFields(s string) []Token {
+	var spans []Token
+	start := -1
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				spans = append(spans, Token{Start: start, End: i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, Token{Start: start, End: len(s)})
+	}
+	return spans
+}
+
+// ampersandRule spells "&" as the language's "and"
+func ampersandRule(and string) Rule {
+	return Rule{
+		Name: RuleAmpersand,
+		Text: func(s string) string {
+			return strings.ReplaceAll(s, "&", " "+and+" ")
+		},
+	}
+}
+
+// contractionRule expands the language's contractions, splits its elisions
+// ("l'amour" is "l amour") and drops the apostrophes left in words, so
+// "don't" is "dont" whether the apostrophe is typed or not
+func contractionRule(lang language) Rule {
+	return Rule{
+		Name: RuleContraction,
+		Text: func(w string) string {
+			w = strings.ReplaceAll(w, "’", "'")
+			if expanded, ok := lang.contractions[w]; ok {
+				return expanded
+			}
+			if !strings.Contains(w, "'") {
+				return w
+			}
+
+			for _, elision := range lang.elisions {
+				if rest, ok := strings.CutPrefix(w, elision); ok && rest != "" {
+					w = elision[:len(elision)-1] + " " + rest
+					break
+				}
+			}
+			if lang.droppedG && strings.HasSuffix(w, "in'") {
+				w = strings.TrimSuffix(w, "'") + "g"
+			}
+			return strings.ReplaceAll(w, "'", "")
+		},
+	}
+}
+
+func symbolsRule() Rule {
+	return Rule{Name: RuleSymbols, Text: RemoveSymbols}
+}
+
+func accentsRule() Rule {
+	return Rule{Name: RuleAccents, Text: RemoveAccent}
+}
+
+// romanRule writes the roman numerals II to XXXIX in digits. Single letters
+// are left alone, "I" is a word and "V" or "X" rarely are numbers
+func romanRule() Rule {
+	return Rule{
+		Name: RuleRoman,
+		Words: func(tokens []Token) []Token {
+			for i, token := range tokens {
+				if n, ok := parseRoman(token.Word); ok {
+					tokens[i].Word = strconv.Itoa(n)
+				}
+			}
+			return tokens
+		},
+	}
+}
+
+// numberWordRule writes the numbers spelled in the language in digits,
+// "twenty one" is 21
+func numberWordRule(lang language) Rule {
+	return Rule{
+		Name: RuleNumberWord,
+		Words: func(tokens []Token) []Token {
+			result := tokens[:0]
+			for i := 0; i < len(tokens); i++ {
+				token := tokens[i]
+				if tens, ok := lang.tens[token.Word]; ok {
+					token.Word = strconv.Itoa(tens)
+					if i+1 < len(tokens) {
+						if unit, ok := lang.numbers[tokens[i+1].Word]; ok && unit > 0 && unit < 10 {
+							token.Word = strconv.Itoa(tens + unit)
+							token.End = tokens[i+1].End
+							i++
+						}
+					}
+				} else if n, ok := lang.numbers[token.Word]; ok {
+					token.Word = strconv.Itoa(n)
+				}
+				result = append(result, token)
+			}
+			return result
+		},
+	}
+}
+
+// stopWordRule drops the articles, unless the title is nothing but articles
+func stopWordRule(lang language) Rule {
+	return Rule{
+		Name: RuleStopWord,
+		Words: func(tokens []Token) []Token {
+			kept := make([]Token, 0, len(tokens))
+			for _, token := range tokens {
+				if !slices.Contains(lang.stopWords, token.Word) {
+					kept = append(kept, token)
+				}
+			}
+			if len(kept) == 0 {
+				return tokens
+			}
+			return kept
+		},
+	}
+}
+
+// parseRoman reads a roman numeral of two letters or more made of I, V and X
+func parseRoman(s string) (int, bool) {
+	if len(s) < 2 || strings.Trim(s, "ivx") != "" {
+		return 0, false
+	}
+
+	values := map[byte]int{'i': 1, 'v': 5, 'x': 10}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		v := values[s[i]]
+		if i+1 < len(s) && v < values[s[i+1]] {
+			n -= v
+		} else {
+			n += v
+		}
+	}
+
+	// only the canonical spelling, "iiii" or "vx" aren't numbers
+	if n <= 0 || toRoman(n) != s {
+		return 0, false
+	}
+	return n, true
+}
+
+func toRoman(n int) string {
+	var b strings.Builder
+	for _, numeral := range []struct {
+		value  int
+		symbol string
+	}{{10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"}, {1, "i"}} {
+		for n >= numeral.value {
+			b.WriteString(numeral.symbol)
+			n -= numeral.value
+		}
+	}
+	return b.String()
+}
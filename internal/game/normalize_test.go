@@ -0,0 +1,115 @@
+package game_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/game"
+)
+
+func TestPipelineWords(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pipeline game.Pipeline
+		input    string
+		expected []string
+	}{
+		{"hyphenated word kept", game.English, "Anti-Hero", []string{"anti", "hero"}},
+		{"spaced dash cut", game.English, "Yesterday - Remastered 2009", []string{"yesterday"}},
+		{"parenthesis cut", game.English, "Hey Jude (Remastered 2015)", []string{"hey", "jude"}},
+		{"brackets cut", game.English, "Hurt [Live]", []string{"hurt"}},
+		{"whole title in parenthesis", game.English, "(I Can't Get No) Satisfaction", []string{"i", "cant", "get", "no", "satisfaction"}},
+		{"featuring cut", game.English, "Stay feat. Justin Bieber", []string{"stay"}},
+		{"ampersand", game.English, "Salt & Pepper", []string{"salt", "and", "pepper"}},
+		{"rock n roll", game.English, "Rock 'n' Roll", []string{"rock", "and", "roll"}},
+		{"rock n roll typed", game.English, "rock n roll", []string{"rock", "and", "roll"}},
+		{"apostrophe dropped", game.English, "Don’t Stop Me Now", []string{"dont", "stop", "me", "now"}},
+		{"dropped g", game.English, "Livin' on a Prayer", []string{"living", "on", "prayer"}},
+		{"number word", game.English, "Two Princes", []string{"2", "princes"}},
+		{"hyphenated number", game.English, "Twenty-One Guns", []string{"21", "guns"}},
+		{"roman numeral", game.English, "Rocky II", []string{"rocky", "2"}},
+		{"single letter not a numeral", game.English, "I Want You", []string{"i", "want", "you"}},
+		{"not a canonical numeral", game.English, "IIII", []string{"iiii"}},
+		{"stop words", game.English, "The Final Countdown", []string{"final", "countdown"}},
+		{"only stop words", game.English, "The", []string{"the"}},
+		{"accents", game.English, "Café Tacvba", []string{"cafe", "tacvba"}},
+		{"spanish", game.PipelineFor("es"), "Tú y Yo & Los Dos", []string{"tu", "y", "yo", "y", "2"}},
+		{"french elision", game.PipelineFor("fr"), "L'Amour Toujours", []string{"amour", "toujours"}},
+		{"german", game.PipelineFor("de"), "Neunundneunzig Luftballons", []string{"neunundneunzig", "luftballons"}},
+		{"german number", game.PipelineFor("de"), "Die Zwölf", []string{"12"}},
+		{"unknown language", game.PipelineFor("xx"), "The Two", []string{"2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.pipeline.Words(tc.input)
+			if !slices.Equal(got, tc.expected) {
+				t.Errorf("Words(%q) = %q, want %q", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizedGuess(t *testing.T) {
+	testCases := []struct {
+		title    string
+		guess    string
+		expected bool
+	}{
+		{"Anti-Hero", "anti hero", true},
+		{"Salt & Pepper", "salt and pepper", true},
+		{"Two Princes", "2 princes", true},
+		{"Rocky II", "rocky two", true},
+		{"Rock 'n' Roll", "rock and roll", true},
+		{"The Final Countdown", "final countdown", true},
+		{"Don't Stop Me Now", "dont stop me now", true},
+		{"Hey Jude - Remastered 2015", "hey jude", true},
+		{"Anti-Hero", "anti", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title+" "+tc.guess, func(t *testing.T) {
+			g := game.NewGameState()
+			g.Matcher = game.ExactMatcher
+			g.SetTitle(tc.title, "Artist", "cover.jpg")
+			_, got := g.Guess(tc.guess)
+			if got != tc.expected {
+				t.Errorf("Guess(%q) on %q = %v, want %v", tc.guess, tc.title, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRemoveSymbols(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"hello", "hello"},
+		{"Hello", "hello"},
+		{"anti-hero", "anti hero"},
+		{"what's up?", "what s up "},
+		{"Ça va", "ça va"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got := game.RemoveSymbols(tc.input)
+			if got != tc.expected {
+				t.Errorf("RemoveSymbols(%q) = %q, want %q", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRemoveSymbolsAllocs(t *testing.T) {
+	clean := testing.AllocsPerRun(100, func() { game.RemoveSymbols("bohemian rhapsody") })
+	if clean != 0 {
+		t.Errorf("RemoveSymbols on clean text allocates %v times, want 0", clean)
+	}
+	symbols := testing.AllocsPerRun(100, func() { game.RemoveSymbols("Bohemian-Rhapsody!") })
+	if symbols > 1 {
+		t.Errorf("RemoveSymbols allocates %v times, want at most 1", symbols)
+	}
+}
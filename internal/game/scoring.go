@@ -0,0 +1,125 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+// Scoring weighs the points of each song, so that naming it fast and many
+// in a row pays
+type Scoring struct {
+	// Grace is how long the title is worth all its points, they then go
+	// down to MinShare of them over Decay
+	Grace    time.Duration
+	Decay    time.Duration
+	MinShare float64
+	// StreakBonus multiplies the title's points once more for each title
+	// named in a row before it, up to MaxMultiplier
+	StreakBonus   float64
+	MaxMultiplier float64
+	// SkipPenalty is taken for each song skipped without naming its title
+	SkipPenalty int
+	// FieldPoints are the points for naming each field, FieldPoints when
+	// not set
+	FieldPoints map[string]int
+}
+
+// DefaultScoring is the scoring of a game unless it is set otherwise
+var DefaultScoring = Scoring{
+	Grace:         5 * time.Second,
+	Decay:         60 * time.Second,
+	MinShare:      0.25,
+	StreakBonus:   0.1,
+	MaxMultiplier: 2,
+	SkipPenalty:   20,
+	FieldPoints:   FieldPoints,
+}
+
+// fieldPoints are the points for naming the field before time and streak
+func (s Scoring) fieldPoints(name string) int {
+	if s.FieldPoints == nil {
+		return FieldPoints[name]
+	}
+	return s.FieldPoints[name]
+}
+
+// RoundScore is how the points of the current song add up, the time, hint
+// and skip parts are 0 or less
+type RoundScore struct {
+	// Elapsed is how long naming the title took
+	Elapsed time.Duration
+	Title   int
+	Time    int
+	// Multiplier is the streak multiplier the title earned Streak with
+	Multiplier float64
+	Streak     int
+	Fields     int
+	Hints      int
+	Skip       int
+}
+
+// Total are the points the song earned
+func (r RoundScore) Total() int {
+	return r.Title + r.Time + r.Streak + r.Fields + r.Hints + r.Skip
+}
+
+// StartTimer sets when the player started hearing the current song, the
+// title is worth less the longer it takes to name
+func (g *GuessState) StartTimer(start time.Time) {
+	g.started = start
+}
+
+// Round is the score of the current song so far
+func (g *GuessState) Round() RoundScore {
+	return g.round
+}
+
+// Streak is how many titles were named in a row
+func (g *GuessState) Streak() int {
+	return g.streak
+}
+
+// Skip moves on from the current song. Unless its title was named it costs
+// the skip penalty, the streak and in survival mode a life
+func (g *GuessState) Skip() {
+	if !g.Guessed() && g.round.Skip == 0 {
+		g.round.Skip = -g.Scoring.SkipPenalty
+		g.points += g.round.Skip
+		g.streak = 0
+		g.LoseLife()
+	}
+	if g.survival && g.lives > 0 {
+		g.survived++
+	}
+}
+
+// scoreTitle scores the title named now and counts it in the streak
+func (g *GuessState) scoreTitle() int {
+	s := g.Scoring
+	elapsed := time.Since(g.started)
+
+	share := 1.0
+	if elapsed > s.Grace && s.Decay > 0 {
+		decayed := min(float64(elapsed-s.Grace)/float64(s.Decay), 1)
+		share = 1 - (1-s.MinShare)*decayed
+	}
+	multiplier := 1.0
+	if s.MaxMultiplier > 1 {
+		multiplier = min(1+s.StreakBonus*float64(g.streak), s.MaxMultiplier)
+	}
+
+	base := g.guessPoints()
+	timed := int(math.Round(float64(base) * share))
+	g.round.Elapsed = elapsed
+	g.round.Title = base
+	g.round.Time = timed - base
+	g.round.Multiplier = multiplier
+	g.round.Streak = int(math.Round(float64(timed) * (multiplier - 1)))
+	g.streak++
+	return timed + g.round.Streak
+}
+
+// Elapsed is how long the current song has been played
+func (g *GuessState) Elapsed() time.Duration {
+	return time.Since(g.started)
+}
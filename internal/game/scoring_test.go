@@ -0,0 +1,113 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FerNunez/NameThatSong/internal/game"
+)
+
+func TestTimeDecay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		elapsed  time.Duration
+		expected int
+	}{
+		{"within grace", 3 * time.Second, 100},
+		{"a quarter of the decay", 20 * time.Second, 81},
+		{"after the decay", 2 * time.Minute, 25},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.SetTitle("Hey Jude", "The Beatles", "cover.jpg")
+			g.StartTimer(time.Now().Add(-tc.elapsed))
+			g.Guess("hey jude")
+
+			if g.GetPoints() != tc.expected {
+				t.Errorf("points after %v = %d, want %d", tc.elapsed, g.GetPoints(), tc.expected)
+			}
+			round := g.Round()
+			if round.Title+round.Time != tc.expected {
+				t.Errorf("Round() = %+v, want title and time adding to %d", round, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStreak(t *testing.T) {
+	testCases := []struct {
+		name     string
+		actions  []string
+		expected []int
+	}{
+		{"in a row", []string{"guess", "guess", "guess"}, []int{100, 110, 120}},
+		{"skip breaks the streak", []string{"guess", "skip", "guess"}, []int{100, -20, 100}},
+		{"reveal breaks the streak", []string{"guess", "reveal", "guess"}, []int{100, -20, 100}},
+		{"capped", []string{"guess", "guess", "guess", "guess"}, []int{100, 110, 120, 125}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.Scoring.MaxMultiplier = 1.25
+			for i, action := range tc.actions {
+				g.SetTitle("Hey Jude", "The Beatles", "cover.jpg")
+				switch action {
+				case "guess":
+					g.Guess("hey jude")
+				case "reveal":
+					g.Reveal()
+				}
+				g.Skip()
+
+				if got := g.Round().Total(); got != tc.expected[i] {
+					t.Errorf("round %d scored %d, want %d (%+v)", i, got, tc.expected[i], g.Round())
+				}
+			}
+		})
+	}
+}
+
+func TestRoundScore(t *testing.T) {
+	g := game.NewGameState()
+	g.SetAnswer(game.Answer{Title: "Hey Jude", Artist: "The Beatles", Year: "1968"})
+	g.Hint(game.HintFirstLetters)
+	g.Guess("hey jude beatles")
+
+	expected := game.RoundScore{Title: 100, Multiplier: 1, Fields: 50, Hints: -25}
+	round := g.Round()
+	round.Elapsed = 0
+	if round != expected {
+		t.Errorf("Round() = %+v, want %+v", round, expected)
+	}
+	if round.Total() != g.GetPoints() {
+		t.Errorf("Total() = %d, want the points %d", round.Total(), g.GetPoints())
+	}
+}
+
+func TestFieldPoints(t *testing.T) {
+	testCases := []struct {
+		name     string
+		points   map[string]int
+		expected int
+	}{
+		{"defaults", nil, 150},
+		{"weighted per game", map[string]int{game.FieldTitle: 200, game.FieldAlbum: 10}, 210},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.Scoring.FieldPoints = tc.points
+			g.Asked = []string{game.FieldAlbum}
+			g.SetAnswer(game.Answer{Title: "Hey Jude", Artist: "The Beatles", Album: "Past Masters"})
+			g.Guess("hey jude past masters")
+
+			if g.GetPoints() != tc.expected {
+				t.Errorf("points = %d, want %d", g.GetPoints(), tc.expected)
+			}
+		})
+	}
+}
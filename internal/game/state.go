@@ -1,17 +1,61 @@
 package game
 
 import (
-	"fmt"
 	"strings"
+	"time"
 )
 
 type GuessState struct {
+	// Title is the first of the Fields
 	Title          *TitleGuessState
+	Fields         []*AnswerField
 	Artist         string
 	AlbumImage     string
 	State          string
 	points         int
 	correctGuesses int
+	revealed       bool
+
+	// Heardle unlocks the song progressively, see HeardleSteps
+	Heardle bool
+	unlocks int
+
+	// Matcher is how forgiving guesses of the next titles are
+	Matcher Matcher
+	// Pipeline normalises the next titles and their guesses
+	Pipeline Pipeline
+	// closeWords are the words of the last guess that nearly matched
+	closeWords []string
+
+	// ShowArtist shows the artist and album cover from the start, they are
+	// hidden until guessed otherwise
+	ShowArtist bool
+	// Asked are the OptionalFields guessed besides the title
+	Asked []string
+	// choices are the titles offered in multiple-choice mode, the ones
+	// ruled out were picked and wrong
+	choices  []string
+	ruledOut []bool
+
+	// HintCosts are the points each hint takes, see DefaultHintCosts
+	HintCosts map[string]int
+	// hints are the hints used on the current song
+	hints []string
+
+	// Scoring weighs the points of the next songs
+	Scoring Scoring
+	// started is when the player started hearing the current song
+	started time.Time
+	round   RoundScore
+	streak  int
+
+	// RoundTime is how long each song can be guessed in survival mode
+	RoundTime time.Duration
+	survival  bool
+	lives     int
+	lifeLost  bool
+	survived  int
+	timedOut  bool
 }
 
 func NewGameState() *GuessState {
@@ -20,57 +64,156 @@ func NewGameState() *GuessState {
 		State:          "",
 		points:         0,
 		correctGuesses: 0,
+		Matcher:        DefaultMatcher,
+		Pipeline:       English,
+		HintCosts:      DefaultHintCosts,
+		Scoring:        DefaultScoring,
+		Asked:          OptionalFields,
 	}
 }
 
 func (g *GuessState) SetTitle(trackName string, artistName string, albumUrl string) {
-	g.Title = NewTitleGuessState(trackName)
-	g.Artist = artistName
-	g.AlbumImage = albumUrl
-	g.State = ""
+	g.SetAnswer(Answer{Title: trackName, Artist: artistName, AlbumImage: albumUrl})
 }
 
-type TitleGuessState struct {
-	RealTitle       string
-	TitleAliveWords map[string]uint8
+// Reveal gives the answer away, the song can't be scored anymore
+func (g *GuessState) Reveal() {
+	g.revealed = true
+	g.State = "Revealed"
+	g.streak = 0
 }
 
-func NewTitleGuessState(titleName string) *TitleGuessState {
-	// array of words
-	words := strings.Split(CleanText(titleName), " ")
+// Revealed tells whether the answer was given away
+func (g *GuessState) Revealed() bool {
+	return g.revealed
+}
+
+// Guessed tells whether every word of the title was found
+func (g *GuessState) Guessed() bool {
+	return len(g.Title.AliveWords()) == 0
+}
 
-	// count ords
-	wordsCounts := make(map[string]uint8, len(words))
+// AnswerShown tells whether the track metadata can be shown to the player
+func (g *GuessState) AnswerShown() bool {
+	return g.revealed || g.Guessed()
+}
 
-	for _, w := range words {
-		if w == "" {
-			continue
+// ShowTitle is the title as the player may see it, featured artists that
+// are still to guess are masked too
+func (g *GuessState) ShowTitle() string {
+	if g.revealed {
+		return g.Title.RealTitle
+	}
+
+	hidden := g.Title.maskedTokens()
+	if len(hidden) == 0 {
+		return g.Title.RealTitle
+	}
+	if featured, ok := g.Field(FieldFeatured); ok && !featured.Guessed() {
+		title := g.Title.RealTitle
+		required := g.Title.Pipeline.Required(title)
+		for _, token := range featured.Words.hiddenTokens() {
+			name := featured.Words.RealTitle[token.Start:token.End]
+			if i := strings.Index(title[required:], name); i >= 0 {
+				hidden = append(hidden, Token{Start: required + i, End: required + i + len(name)})
+			}
 		}
-		wordsCounts[w] += 1
 	}
+	return maskSpans(g.Title.RealTitle, hidden)
+}
 
-	fmt.Printf("wc: %v\n", wordsCounts)
+// TitleGuessState follows which words of the title were found. Each word
+// keeps the span of the title it is written in, so the title is masked and
+// revealed exactly as it is written. The version or featured artists after
+// the words are shown but don't need to be guessed
+type TitleGuessState struct {
+	RealTitle string
+	Tokens    []Token
+	Matcher   Matcher
+	Pipeline  Pipeline
+	found     []bool
+	// shown are how many bytes of each word hints have shown
+	shown []int
+}
+
+func NewTitleGuessState(titleName string) *TitleGuessState {
+	return newTitleGuessState(titleName, DefaultMatcher, English)
+}
+
+func newTitleGuessState(titleName string, matcher Matcher, pipeline Pipeline) *TitleGuessState {
+	tokens := pipeline.Tokens(titleName)
 
 	return &TitleGuessState{
-		RealTitle:       titleName,
-		TitleAliveWords: wordsCounts,
+		RealTitle: titleName,
+		Tokens:    tokens,
+		Matcher:   matcher,
+		Pipeline:  pipeline,
+		found:     make([]bool, len(tokens)),
+		shown:     make([]int, len(tokens)),
 	}
 }
 
+// AliveWords are the words of the title left to guess
+func (t *TitleGuessState) AliveWords() []string {
+	var words []string
+	for i, token := range t.Tokens {
+		if !t.found[i] {
+			words = append(words, token.Word)
+		}
+	}
+	return words
+}
+
 func (g *GuessState) Guess(text string) (string, bool) {
-	// update Guess
-	g.Title.updateGuessState(text)
+	if g.revealed {
+		return g.ShowTitle(), false
+	}
+
+	// each word names the word of any field it matches best
+	found := 0
+	g.closeWords = nil
+	for _, w := range g.Pipeline.Words(text) {
+		field, i, match := g.bestMatch(w)
+		switch match {
+		case MatchExact, MatchFuzzy:
+			found++
+			field.Words.found[i] = true
+		case MatchClose:
+			g.closeWords = append(g.closeWords, w)
+		}
+	}
+
+	return g.Title.ShowGuessState(), g.settle(found)
+}
+
+// settle scores the fields named by a guess that found that many words and
+// tells whether the title is guessed
+func (g *GuessState) settle(found int) bool {
+	// the fields score once, when their last word is found
+	for _, field := range g.Fields {
+		if field.points > 0 || field.given || !field.Guessed() {
+			continue
+		}
+		if field.Name == FieldTitle {
+			field.points = g.scoreTitle()
+		} else {
+			field.points = g.Scoring.fieldPoints(field.Name)
+			g.round.Fields += field.points
+		}
+		g.points += field.points
+	}
 
 	g.State = "Keep guessing.."
 	// Check if all words are guessed
-	allGuessed := len(g.Title.TitleAliveWords) == 0
+	allGuessed := g.Guessed()
 	if allGuessed {
-		g.points += 100 // Award 100 points for a correct guess
 		g.correctGuesses++
 		g.State = "Correct!"
+	} else if found == 0 {
+		// a wrong guess unlocks more of the song
+		g.Unlock()
 	}
-
-	return g.Title.ShowGuessState(), allGuessed
+	return allGuessed
 }
 
 func (g *GuessState) GetPoints() int {
@@ -81,23 +224,52 @@ func (g *GuessState) GetCorrectGuesses() int {
 	return g.correctGuesses
 }
 
-func (t *TitleGuessState) updateGuessState(text string) {
-	words := strings.Split(text, " ")
+// CloseWords are the words of the last guess that nearly named a word of
+// the title
+func (g *GuessState) CloseWords() []string {
+	return g.closeWords
+}
 
-	for _, w := range words {
-		wLow := CleanText(strings.ToLower(w))
-		remaining, ok := t.TitleAliveWords[wLow]
-		if ok {
-			if remaining == 1 {
-				delete(t.TitleAliveWords, wLow)
-				continue
-			}
-			t.TitleAliveWords[wLow] -= 1
+// bestMatch finds the remaining title word the guessed word matches best,
+// ties go to the first one in the title
+func (t *TitleGuessState) bestMatch(guess string) (int, Match) {
+	best, bestMatch := -1, MatchNone
+	for i, token := range t.Tokens {
+		if t.found[i] {
+			continue
+		}
+		match := t.Matcher.Match(guess, token.Word)
+		if match > bestMatch {
+			best, bestMatch = i, match
 		}
 	}
+	return best, bestMatch
 }
 
 func (t TitleGuessState) ShowGuessState() string {
+	return maskSpans(t.RealTitle, t.maskedTokens())
+}
 
-	return ProcessState(t.RealTitle, t.TitleAliveWords)
+// hiddenTokens are the words left to guess
+func (t TitleGuessState) hiddenTokens() []Token {
+	var hidden []Token
+	for i, token := range t.Tokens {
+		if !t.found[i] {
+			hidden = append(hidden, token)
+		}
+	}
+	return hidden
+}
+
+// maskedTokens are the parts of the words left to guess that no hint has
+// shown
+func (t TitleGuessState) maskedTokens() []Token {
+	var masked []Token
+	for i, token := range t.Tokens {
+		if !t.found[i] {
+			token.Start += t.shown[i]
+			masked = append(masked, token)
+		}
+	}
+	return masked
 }
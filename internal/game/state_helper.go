@@ -7,15 +7,9 @@ import (
 	"golang.org/x/text/unicode/norm"
 )
 
+// CleanText is the text normalised in English as one string
 func CleanText(s string) string {
-	cleanText := RemoveParenthesis(strings.ToLower(s))
-	cleanText = RemoveAfterWord(cleanText, "-")
-	cleanText = RemoveAfterWord(cleanText, "feat.")
-	cleanText = RemoveAfterWord(cleanText, "feature")
-	cleanText = RemoveSymbols(cleanText)
-	cleanText = RemoveAccent(cleanText)
-
-	return cleanText
+	return strings.Join(English.Words(s), " ")
 }
 
 func RemoveAccent(s string) string {
@@ -33,12 +27,17 @@ func RemoveAccent(s string) string {
 }
 
 func RemoveParenthesis(s string) string {
+	return cutEnclosed(s, "(", ")")
+}
 
-	splitted := strings.SplitN(s, "(", 2)
+// cutEnclosed cuts the text at the opening bracket, when it is closed
+func cutEnclosed(s string, open string, close string) string {
+
+	splitted := strings.SplitN(s, open, 2)
 
 	if len(splitted) > 1 {
-		if !strings.Contains(splitted[1], ")") {
-			return strings.Join(splitted, "(")
+		if !strings.Contains(splitted[1], close) {
+			return strings.Join(splitted, open)
 		}
 	}
 	return splitted[0]
@@ -54,59 +53,31 @@ func RemoveAfterWord(s string, w string) string {
 	return splitted[0]
 }
 
+// RemoveSymbols lowercases the text and replaces everything that isn't a
+// letter, a number or an accent by a space. The text is only copied when it
+// changes
 func RemoveSymbols(s string) string {
-
-	// remove symbol
-	noSymbol := ""
-	for _, r := range strings.ToLower(s) {
-		// remove symbol
-		if unicode.IsLetter(r) || unicode.IsNumber(r) {
-			noSymbol += string(r)
-		} else {
-			noSymbol += " "
+	clean := func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) {
+			return unicode.ToLower(r)
 		}
+		return ' '
 	}
 
-	return noSymbol
-}
-func ProcessState(original string, aliveWords map[string]uint8) string {
-
-	if len(aliveWords) == 0 {
-		return original
+	i := strings.IndexFunc(s, func(r rune) bool { return clean(r) != r })
+	if i < 0 {
+		return s
 	}
 
-	original = RemoveSymbols(original)
-	original = RemoveAccent(original)
-
-	solution := ""
-	words := strings.Split(original, " ")
-	for _, w := range words {
-
-		// remove symbol
-		// noSymbol := ""
-		// for _, r := range strings.ToLower(w) {
-		// 	// remove symbol
-		// 	if unicode.IsLetter(r) || unicode.IsNumber(r) {
-		// 		noSymbol += string(r)
-		// 	}
-		// }
-		//
-		_, ok := aliveWords[w]
-		if !ok {
-			solution += w
-		} else {
-
-			for _, r := range w {
-				// is symbol
-				if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
-					solution += string(r)
-				} else {
-					solution += "_ "
-				}
-			}
-		}
-		solution += " "
+	var b strings.Builder
+	b.Grow(len(s))
+	b.WriteString(s[:i])
+	for _, r := range s[i:] {
+		b.WriteRune(clean(r))
 	}
+	return b.String()
+}
 
-	return strings.Trim(solution, " ")
+func ProcessState(original string, aliveWords map[string]uint8) string {
+	return English.Mask(original, aliveWords)
 }
@@ -1,7 +1,9 @@
 package game_test
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/FerNunez/NameThatSong/internal/game"
 )
@@ -92,32 +94,32 @@ func TestProcessState(t *testing.T) {
 		{
 			"Hello world!",
 			map[string]uint8{"hello": 1},
-			"_ _ _ _ _  world!", // Replace Hello with underscore, keep 'world!' as it is
+			"_____ world!", // Replace Hello with underscores, keep 'world!' as it is
 		},
 		{
 			"Go programming is fun!",
 			map[string]uint8{"go": 1, "fun": 1},
-			"_ _  programming is _ _ _ !", // Replace 'Go' and 'fun' with underscores
+			"__ programming is ___!", // Replace 'Go' and 'fun' with underscores
 		},
 		{
 			"Hello, how are you?",
 			map[string]uint8{"how": 1, "you": 1},
-			"Hello, _ _ _  are _ _ _ ?", // Replace 'how' and 'you' with underscores
+			"Hello, ___ are ___?", // Replace 'how' and 'you' with underscores
 		},
 		{
 			"Test123 is here!",
 			map[string]uint8{"test123": 1},
-			"_ _ _ _ _ _ _  is here!", // Replace 'Test123' with underscore, keep 'is here!' intact
+			"_______ is here!", // Replace 'Test123' with underscores, keep 'is here!' intact
 		},
 		{
 			"Start testing symbols: @#%$!",
 			map[string]uint8{"testing": 1},
-			"Start _ _ _ _ _ _ _  symbols: @#%$!", // Replace 'testing' with underscores
+			"Start _______ symbols: @#%$!", // Replace 'testing' with underscores
 		},
 		{
 			"Test symbols and spaces.",
 			map[string]uint8{"symbols": 1},
-			"Test _ _ _ _ _ _ _  and spaces.", // Replace 'symbols' with underscores
+			"Test _______ and spaces.", // Replace 'symbols' with underscores
 		},
 	}
 
@@ -131,3 +133,377 @@ func TestProcessState(t *testing.T) {
 		})
 	}
 }
+
+func TestReveal(t *testing.T) {
+	testCases := []struct {
+		name          string
+		guesses       []string
+		reveal        bool
+		expectedShown bool
+		expectedTitle string
+		expectedScore int
+	}{
+		{"nothing guessed", []string{"nope"}, false, false, "", 0},
+		{"title guessed", []string{"hello world"}, false, true, "Hello world", 100},
+		{"revealed", []string{"hello"}, true, true, "Hello world", 0},
+		{"guess after reveal", []string{"hello world"}, true, true, "Hello world", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.SetTitle("Hello world", "Artist", "cover.jpg")
+			if tc.reveal {
+				g.Reveal()
+			}
+			for _, guess := range tc.guesses {
+				g.Guess(guess)
+			}
+
+			if g.AnswerShown() != tc.expectedShown {
+				t.Errorf("AnswerShown() = %v, want %v", g.AnswerShown(), tc.expectedShown)
+			}
+			if tc.expectedShown && g.ShowTitle() != tc.expectedTitle {
+				t.Errorf("ShowTitle() = %q, want %q", g.ShowTitle(), tc.expectedTitle)
+			}
+			if g.GetPoints() != tc.expectedScore {
+				t.Errorf("GetPoints() = %v, want %v", g.GetPoints(), tc.expectedScore)
+			}
+		})
+	}
+}
+
+func TestHeardle(t *testing.T) {
+	testCases := []struct {
+		name            string
+		heardle         bool
+		guesses         []string
+		expectedUnlocks int
+		expectedLength  time.Duration
+		expectedPoints  int
+	}{
+		{"first try", true, []string{"hello world"}, 0, time.Second, 100},
+		{"one wrong guess", true, []string{"nope", "hello world"}, 1, 2 * time.Second, 80},
+		{"partial guess keeps the length", true, []string{"hello", "world"}, 0, time.Second, 100},
+		{"all unlocked", true, []string{"a", "b", "c", "d", "e", "f", "g"}, 5, 16 * time.Second, 0},
+		{"all unlocked then named", true, []string{"a", "b", "c", "d", "e", "f", "hello world"}, 5, 16 * time.Second, 10},
+		{"mode off", false, []string{"nope", "hello world"}, 0, time.Second, 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.SetHeardle(tc.heardle)
+			g.SetTitle("Hello world", "Artist", "cover.jpg")
+			for _, guess := range tc.guesses {
+				g.Guess(guess)
+			}
+
+			if g.Unlocks() != tc.expectedUnlocks {
+				t.Errorf("Unlocks() = %v, want %v", g.Unlocks(), tc.expectedUnlocks)
+			}
+			if g.UnlockedLength() != tc.expectedLength {
+				t.Errorf("UnlockedLength() = %v, want %v", g.UnlockedLength(), tc.expectedLength)
+			}
+			if g.GetPoints() != tc.expectedPoints {
+				t.Errorf("GetPoints() = %v, want %v", g.GetPoints(), tc.expectedPoints)
+			}
+		})
+	}
+}
+
+func TestTitleSpans(t *testing.T) {
+	testCases := []struct {
+		name          string
+		title         string
+		guesses       []string
+		expectedTitle string
+		expectedAlive []string
+	}{
+		{"nothing guessed", "Hello, World!", nil, "_____, _____!", []string{"hello", "world"}},
+		{"accents kept when found", "Café Tacvba", []string{"cafe"}, "Café ______", []string{"tacvba"}},
+		{"accented letter masked", "Café Tacvba", []string{"tacvba"}, "____ Tacvba", []string{"cafe"}},
+		{"hyphenated half found", "Anti-Hero", []string{"hero"}, "____-Hero", []string{"anti"}},
+		{"version not required", "Hey Jude - Remastered 2015", nil, "___ ____ - Remastered 2015", []string{"hey", "jude"}},
+		{"version shown when found", "Hey Jude - Remastered 2015", []string{"hey jude"}, "Hey Jude - Remastered 2015", nil},
+		{"parenthesis not required", "Yesterday (Remastered 2009)", nil, "_________ (Remastered 2009)", []string{"yesterday"}},
+		{"featuring not required", "Stay feat. Justin Bieber", nil, "____ feat. ______ ______", []string{"stay"}},
+		{"featuring shown when named", "Stay feat. Justin Bieber", []string{"justin bieber"}, "____ feat. Justin Bieber", []string{"stay"}},
+		{"stop word shown", "The Final Countdown", nil, "The _____ _________", []string{"final", "countdown"}},
+		{"first repeated word found first", "New York, New York", []string{"new york"}, "New York, ___ ____", []string{"new", "york"}},
+		{"number spelled over two words", "Twenty One Pilots", []string{"pilots"}, "______ ___ Pilots", []string{"21"}},
+		{"spacing kept", "Hello  World", nil, "_____  _____", []string{"hello", "world"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.Matcher = game.ExactMatcher
+			g.SetTitle(tc.title, "Artist", "cover.jpg")
+			for _, guess := range tc.guesses {
+				g.Guess(guess)
+			}
+
+			if g.ShowTitle() != tc.expectedTitle {
+				t.Errorf("ShowTitle() = %q, want %q", g.ShowTitle(), tc.expectedTitle)
+			}
+			if !slices.Equal(g.Title.AliveWords(), tc.expectedAlive) {
+				t.Errorf("AliveWords() = %q, want %q", g.Title.AliveWords(), tc.expectedAlive)
+			}
+		})
+	}
+}
+
+func TestAnswerFields(t *testing.T) {
+	answer := game.Answer{
+		Title:      "Under Pressure",
+		Artist:     "Queen",
+		Featured:   []string{"David Bowie"},
+		Album:      "Hot Space",
+		Year:       "1982-05-21",
+		AlbumImage: "cover.jpg",
+	}
+
+	testCases := []struct {
+		name           string
+		showArtist     bool
+		guesses        []string
+		expectedPoints int
+		expectedArtist string
+		expectedCover  bool
+		expectedDone   bool
+	}{
+		{"nothing guessed", false, nil, 0, "_____", false, false},
+		{"artist", false, []string{"queen"}, 50, "Queen", false, false},
+		{"album shows the cover", false, []string{"hot space"}, 50, "_____", true, false},
+		{"everything at once", false, []string{"under pressure queen david bowie hot space 1982"}, 250, "Queen", true, true},
+		{"fields one by one", false, []string{"1982", "bowie", "david", "pressure", "under"}, 150, "Queen", true, true},
+		{"wrong year", false, []string{"1983"}, 0, "_____", false, false},
+		{"artist shown from the start", true, []string{"queen under pressure"}, 100, "Queen", true, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.ShowArtist = tc.showArtist
+			g.SetAnswer(answer)
+			for _, guess := range tc.guesses {
+				g.Guess(guess)
+			}
+
+			if g.GetPoints() != tc.expectedPoints {
+				t.Errorf("GetPoints() = %v, want %v", g.GetPoints(), tc.expectedPoints)
+			}
+			artist := g.ShowField(game.FieldArtist)
+			if g.ArtistShown() && artist == "" {
+				artist = g.Artist
+			}
+			if artist != tc.expectedArtist {
+				t.Errorf("artist shown = %q, want %q", artist, tc.expectedArtist)
+			}
+			if g.CoverShown() != tc.expectedCover {
+				t.Errorf("CoverShown() = %v, want %v", g.CoverShown(), tc.expectedCover)
+			}
+			if g.Guessed() != tc.expectedDone {
+				t.Errorf("Guessed() = %v, want %v", g.Guessed(), tc.expectedDone)
+			}
+		})
+	}
+}
+
+func TestAskedFields(t *testing.T) {
+	answer := game.Answer{Title: "Under Pressure", Artist: "Queen", Album: "Hot Space", Year: "1982"}
+	testCases := []struct {
+		name     string
+		asked    []string
+		expected []string
+	}{
+		{"all fields", game.OptionalFields, []string{game.FieldTitle, game.FieldArtist, game.FieldAlbum, game.FieldYear}},
+		{"title only", nil, []string{game.FieldTitle}},
+		{"album and year", []string{game.FieldAlbum, game.FieldYear}, []string{game.FieldTitle, game.FieldAlbum, game.FieldYear}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.Asked = tc.asked
+			g.SetAnswer(answer)
+
+			var got []string
+			for _, field := range g.Fields {
+				got = append(got, field.Name)
+			}
+			if !slices.Equal(got, tc.expected) {
+				t.Errorf("fields = %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAnswerWord(t *testing.T) {
+	testCases := []struct {
+		word     string
+		expected bool
+	}{
+		{"love", true},
+		{"pleese", true},
+		{"do", true},
+		{"beatles", true},
+		{"please", true},
+		{"queen", false},
+	}
+
+	g := game.NewGameState()
+	g.SetAnswer(game.Answer{Title: "Love Me Do", Artist: "The Beatles", Album: "Please Please Me"})
+	// found words still name the answer
+	g.Guess("love")
+	for _, tc := range testCases {
+		t.Run(tc.word, func(t *testing.T) {
+			if got := g.AnswerWord(tc.word); got != tc.expected {
+				t.Errorf("AnswerWord(%q) = %v, want %v", tc.word, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFeaturedArtists(t *testing.T) {
+	testCases := []struct {
+		title    string
+		expected []string
+	}{
+		{"Stay", nil},
+		{"Stay (feat. Justin Bieber)", []string{"Justin Bieber"}},
+		{"Stay (with Justin Bieber)", nil},
+		{"Song ft. A, B & C", []string{"A", "B", "C"}},
+		{"Song [Feat. A and B] - Remix", []string{"A", "B"}},
+		{"Feat Of Clay", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			got := game.FeaturedArtists(tc.title)
+			if !slices.Equal(got, tc.expected) {
+				t.Errorf("FeaturedArtists(%q) = %q, want %q", tc.title, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestChoose(t *testing.T) {
+	choices := []string{"Yesterday", "Let It Be", "Hey Jude", "Help!"}
+	testCases := []struct {
+		name     string
+		picks    []int
+		expected bool
+		points   int
+		state    string
+	}{
+		{"right choice", []int{2}, true, 100, "Correct!"},
+		{"wrong choice", []int{0}, false, 0, "Wrong choice"},
+		{"wrong then right", []int{0, 2}, true, 100, "Correct!"},
+		{"same wrong choice twice", []int{0, 0}, false, 0, "Wrong choice"},
+		{"out of range", []int{4}, false, 0, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.SetTitle("Hey Jude", "The Beatles", "cover.jpg")
+			g.SetChoices(choices)
+
+			var got bool
+			for _, pick := range tc.picks {
+				got = g.Choose(pick)
+			}
+			if got != tc.expected {
+				t.Errorf("Choose(%v) = %v, want %v", tc.picks, got, tc.expected)
+			}
+			if g.GetPoints() != tc.points {
+				t.Errorf("points = %d, want %d", g.GetPoints(), tc.points)
+			}
+			if g.State != tc.state {
+				t.Errorf("state = %q, want %q", g.State, tc.state)
+			}
+			if g.RuledOut(0) != slices.Contains(tc.picks, 0) {
+				t.Errorf("RuledOut(0) = %v after %v", g.RuledOut(0), tc.picks)
+			}
+		})
+	}
+}
+
+func TestChooseHeardle(t *testing.T) {
+	g := game.NewGameState()
+	g.SetHeardle(true)
+	g.SetTitle("Hey Jude", "The Beatles", "cover.jpg")
+	g.SetChoices([]string{"Yesterday", "Hey Jude"})
+
+	g.Choose(0)
+	if g.Unlocks() != 1 {
+		t.Errorf("Unlocks() = %d after a wrong choice, want 1", g.Unlocks())
+	}
+	if !g.Choose(1) || g.GetPoints() != game.HeardlePoints[1] {
+		t.Errorf("points = %d after a right choice, want %d", g.GetPoints(), game.HeardlePoints[1])
+	}
+}
+
+func TestHint(t *testing.T) {
+	testCases := []struct {
+		name   string
+		title  string
+		hints  []string
+		shown  string
+		points int
+	}{
+		{"letter", "Hey Jude", []string{game.HintLetter}, "H__ ____", -10},
+		{"letters in order", "Hey Jude", []string{game.HintLetter, game.HintLetter, game.HintLetter, game.HintLetter}, "Hey J___", -40},
+		{"first letters", "Hey Jude", []string{game.HintFirstLetters}, "H__ J___", -25},
+		{"first letters once", "Hey Jude", []string{game.HintFirstLetters, game.HintFirstLetters}, "H__ J___", -25},
+		{"letter after first letters", "Hey Jude", []string{game.HintFirstLetters, game.HintLetter}, "He_ J___", -35},
+		{"letter skips apostrophe", "'Round Midnight", []string{game.HintLetter}, "'R____ ________", -10},
+		{"letter keeps accent", "Éxito", []string{game.HintLetter}, "É____", -10},
+		{"cover", "Hey Jude", []string{game.HintCover, game.HintCover}, "___ ____", -15},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.SetTitle(tc.title, "The Beatles", "cover.jpg")
+			for _, hint := range tc.hints {
+				g.Hint(hint)
+			}
+			if got := g.ShowTitle(); got != tc.shown {
+				t.Errorf("ShowTitle() = %q, want %q", got, tc.shown)
+			}
+			if g.GetPoints() != tc.points {
+				t.Errorf("points = %d, want %d", g.GetPoints(), tc.points)
+			}
+			if g.HintPoints() != -tc.points {
+				t.Errorf("HintPoints() = %d, want %d", g.HintPoints(), -tc.points)
+			}
+		})
+	}
+}
+
+func TestHintArtist(t *testing.T) {
+	g := game.NewGameState()
+	g.SetTitle("Hey Jude", "The Beatles", "cover.jpg")
+
+	if !g.Hint(game.HintArtist) || !g.ArtistShown() {
+		t.Fatalf("artist not shown after the artist hint")
+	}
+	if g.HintAvailable(game.HintArtist) {
+		t.Errorf("artist hint still available once the artist is shown")
+	}
+	// the artist given by the hint doesn't score
+	if g.Guess("beatles hey jude"); g.GetPoints() != 100-game.DefaultHintCosts[game.HintArtist] {
+		t.Errorf("points = %d, want %d", g.GetPoints(), 100-game.DefaultHintCosts[game.HintArtist])
+	}
+	if !slices.Equal(g.HintsUsed(), []string{game.HintArtist}) {
+		t.Errorf("HintsUsed() = %q", g.HintsUsed())
+	}
+
+	g.SetTitle("Let It Be", "The Beatles", "cover.jpg")
+	if len(g.HintsUsed()) != 0 || g.HintPoints() != 0 {
+		t.Errorf("hints not reset on the next song: %q", g.HintsUsed())
+	}
+}
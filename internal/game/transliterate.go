@@ -0,0 +1,209 @@
+package game
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// RuleTransliterate is the name of the transliteration rule
+const RuleTransliterate = "transliterate"
+
+// transliterateRule spells the titles and guesses written in another
+// script in Latin letters, so either can be typed
+func transliterateRule() Rule {
+	return Rule{Name: RuleTransliterate, Text: Transliterate}
+}
+
+// Transliterate romanises the lowercase Cyrillic, Greek, kana and Hangul
+// letters of the text, the other letters are kept. Chinese characters
+// can't be romanised without a dictionary, each is made a word of its own
+// so they can be guessed one by one
+func Transliterate(s string) string {
+	if strings.IndexFunc(s, func(r rune) bool { return r >= 0x370 }) < 0 {
+		return s
+	}
+
+	runes := []rune(norm.NFC.String(s))
+	out := make([]byte, 0, 2*len(s))
+	// where the last kana syllable starts in out, -1 when it isn't kana
+	syllable := -1
+	sokuon := false
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		var prev, next rune
+		if i > 0 {
+			prev = runes[i-1]
+		}
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case isKana(r):
+			out, syllable, sokuon = appendKana(out, r, syllable, sokuon)
+			continue
+
+		case r >= 0xAC00 && r <= 0xD7A3:
+			out = appendHangul(out, r, next)
+
+		case isHan(r):
+			out = append(out, ' ')
+			out = append(out, string(r)...)
+			out = append(out, ' ')
+
+		case r == 'υ' || r == 'ύ':
+			// diphthongs: ου is u, αυ and ευ are av and ev
+			switch prev {
+			case 'ο', 'ό':
+				out = append(out, 'u')
+			case 'α', 'ε', 'ά', 'έ':
+				out = append(out, 'v')
+			default:
+				out = append(out, 'y')
+			}
+
+		default:
+			if latin, ok := cyrillicGreek[r]; ok {
+				out = append(out, latin...)
+			} else {
+				out = append(out, string(r)...)
+			}
+		}
+		syllable, sokuon = -1, false
+	}
+	return string(out)
+}
+
+var cyrillicGreek = map[rune]string{
+	// Russian and Ukrainian
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+	// Greek, ELOT 743 without the diacritics
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+	'ά': "a", 'έ': "e", 'ή': "i", 'ί': "i", 'ό': "o", 'ώ': "o", 'ϊ': "i", 'ΐ': "i",
+	'ϋ': "y", 'ΰ': "y",
+}
+
+// kana are spelled in Hepburn romanisation, katakana as the hiragana they
+// sound like
+var kana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ゎ': "wa", 'ゕ': "ka", 'ゖ': "ke",
+	// voiced wa row, only written in katakana
+	'ヷ': "va", 'ヸ': "vi", 'ヹ': "ve", 'ヺ': "vo",
+}
+
+// small kana change the vowel of the syllable before them
+var (
+	smallY      = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+	smallVowels = map[rune]string{'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o"}
+)
+
+func isKana(r rune) bool {
+	return (r >= 0x3041 && r <= 0x3096) || (r >= 0x30A1 && r <= 0x30FC)
+}
+
+// appendKana spells the kana after the syllable starting at index
+// syllable of out. A small tsu doubles the next consonant and the long
+// vowel mark is left out, as on most romanised titles
+func appendKana(out []byte, r rune, syllable int, sokuon bool) ([]byte, int, bool) {
+	if r == 'ー' {
+		return out, syllable, sokuon
+	}
+	if r >= 0x30A1 && r <= 0x30F6 {
+		r -= 0x60
+	}
+
+	switch {
+	case r == 'っ':
+		return out, -1, true
+
+	case smallY[r] != "" && syllable >= 0 && strings.HasSuffix(string(out[syllable:]), "i"):
+		// きゃ is kya but しゃ is sha
+		out = out[:len(out)-1]
+		last := string(out[syllable:])
+		if !strings.HasSuffix(last, "sh") && !strings.HasSuffix(last, "ch") && !strings.HasSuffix(last, "j") {
+			out = append(out, 'y')
+		}
+		return append(out, smallY[r]...), syllable, false
+
+	case smallVowels[r] != "" && syllable >= 0:
+		// ファ is fa and ティ ti, ウィ is wi
+		last := string(out[syllable:])
+		out = out[:syllable]
+		if len(last) == 1 {
+			out = append(out, 'w')
+		} else {
+			out = append(out, last[:len(last)-1]...)
+		}
+		return append(out, smallVowels[r]...), syllable, false
+	}
+
+	latin, ok := kana[r]
+	if !ok {
+		latin = smallY[r] + smallVowels[r]
+	}
+	if latin == "" {
+		// the marks without a sound start no syllable
+		return out, -1, false
+	}
+	start := len(out)
+	if sokuon && !strings.ContainsAny(latin[:1], "aeiou") {
+		if strings.HasPrefix(latin, "ch") {
+			out = append(out, 't')
+		} else {
+			out = append(out, latin[0])
+		}
+	}
+	return append(out, latin...), start, false
+}
+
+// Hangul in Revised Romanisation: a syllable is an initial consonant, a
+// vowel and a final consonant, which is read as the next initial before a
+// silent ㅇ
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulVowels   = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+	hangulLinked   = []string{"", "g", "kk", "ks", "n", "nj", "n", "d", "r", "lg", "lm", "lb", "ls", "lt", "lp", "r", "m", "b", "ps", "s", "ss", "ng", "j", "ch", "k", "t", "p", ""}
+)
+
+const silentInitial = 11
+
+func appendHangul(out []byte, r rune, next rune) []byte {
+	index := int(r - 0xAC00)
+	initial, vowel, final := index/(21*28), index%(21*28)/28, index%28
+
+	out = append(out, hangulInitials[initial]...)
+	out = append(out, hangulVowels[vowel]...)
+	if next >= 0xAC00 && next <= 0xD7A3 && int(next-0xAC00)/(21*28) == silentInitial {
+		return append(out, hangulLinked[final]...)
+	}
+	return append(out, hangulFinals[final]...)
+}
+
+func isHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
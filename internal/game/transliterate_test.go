@@ -0,0 +1,108 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/game"
+)
+
+func TestTransliterate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"latin untouched", "bohemian rhapsody", "bohemian rhapsody"},
+		{"russian", "кино", "kino"},
+		{"russian digraphs", "группа крови", "gruppa krovi"},
+		{"ukrainian", "їжак", "yizhak"},
+		{"greek", "καλημέρα", "kalimera"},
+		{"greek diphthong", "ουρανός", "ouranos"},
+		{"hiragana", "さくら", "sakura"},
+		{"katakana", "カラオケ", "karaoke"},
+		{"voiced kana", "ありがとう", "arigatou"},
+		{"small ya", "とうきょう", "toukyou"},
+		{"sha", "しゃしん", "shashin"},
+		{"small tsu", "きって", "kitte"},
+		{"small tsu before chi", "マッチ", "matchi"},
+		{"long vowel", "ラーメン", "ramen"},
+		{"loanword", "ファンタジー", "fantaji"},
+		{"voiced wa", "ヷァ", "va"},
+		{"voiced wa row", "ヸヹヺ", "vivevo"},
+		{"small ke", "ヶィ", "ki"},
+		{"small ka hiragana", "ゕぁ", "ka"},
+		{"small wa", "ゎぁ", "wa"},
+		{"middle dot", "ラ・ァ", "raa"},
+		{"hangul", "사랑해", "saranghae"},
+		{"hangul final consonant", "안녕", "annyeong"},
+		{"hangul linked consonant", "한국어", "hangugeo"},
+		{"chinese characters", "月亮", " 月  亮 "},
+		{"mixed scripts", "кино 2", "kino 2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := game.Transliterate(tc.input)
+			if got != tc.expected {
+				t.Errorf("Transliterate(%q) = %q, want %q", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTransliteratedGuess(t *testing.T) {
+	testCases := []struct {
+		title    string
+		guess    string
+		expected bool
+	}{
+		{"Группа крови", "gruppa krovi", true},
+		{"Kino", "кино", true},
+		{"さくら", "sakura", true},
+		{"Sakura", "サクラ", true},
+		{"강남스타일", "gangnamseutail", true},
+		{"月亮代表我的心", "月亮代表我的心", true},
+		{"月亮代表我的心", "月亮", false},
+		{"Vanilla", "ヷァ ヶィ ゕぁ", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title+" "+tc.guess, func(t *testing.T) {
+			g := game.NewGameState()
+			g.Matcher = game.ExactMatcher
+			g.SetTitle(tc.title, "Artist", "cover.jpg")
+			_, got := g.Guess(tc.guess)
+			if got != tc.expected {
+				t.Errorf("Guess(%q) on %q = %v, want %v", tc.guess, tc.title, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMaskTransliterated(t *testing.T) {
+	testCases := []struct {
+		name     string
+		title    string
+		guesses  []string
+		expected string
+	}{
+		{"cyrillic", "Группа крови", []string{"krovi"}, "______ крови"},
+		{"kana", "さくら さくら", nil, "___ ___"},
+		{"chinese guessed characters", "月亮代表", []string{"月 代"}, "月_代_"},
+		{"greek accents", "Καλημέρα κόσμε", []string{"kosme"}, "________ κόσμε"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := game.NewGameState()
+			g.SetTitle(tc.title, "Artist", "cover.jpg")
+			for _, guess := range tc.guesses {
+				g.Guess(guess)
+			}
+			got := g.ShowTitle()
+			if got != tc.expected {
+				t.Errorf("ShowTitle() = %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
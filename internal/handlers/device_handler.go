@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/FerNunez/NameThatSong/internal/manager"
+	"github.com/FerNunez/NameThatSong/internal/service"
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+	"github.com/FerNunez/NameThatSong/internal/templates"
+)
+
+type GetDevices struct {
+	gm *manager.GameManager
+}
+
+func NewGetDevices(gm *manager.GameManager) *GetDevices {
+	return &GetDevices{gm}
+}
+
+func (h *GetDevices) ServeHttp(w http.ResponseWriter, r *http.Request) {
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v\n", err)
+		return
+	}
+
+	devices, err := game.GetDevices(r.Context())
+	if err != nil {
+		fmt.Printf("error getting devices: %v\n", err)
+		status, message := providerError(w, err, http.StatusBadRequest, "Could not list Spotify devices")
+		w.WriteHeader(status)
+		component := templates.SearchError(message)
+		component.Render(r.Context(), w)
+		return
+	}
+
+	component := templates.DevicePicker(devices, game.DeviceId, "")
+	component.Render(r.Context(), w)
+}
+
+//////////////////
+
+type PostSelectDevice struct {
+	gm *manager.GameManager
+}
+
+func NewPostSelectDevice(gm *manager.GameManager) *PostSelectDevice {
+	return &PostSelectDevice{gm}
+}
+
+func (h *PostSelectDevice) ServeHttp(w http.ResponseWriter, r *http.Request) {
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	deviceID := r.Form.Get("deviceID")
+	if deviceID == "" {
+		http.Error(w, "Device ID is required", http.StatusBadRequest)
+		return
+	}
+
+	err = game.SelectDevice(r.Context(), deviceID)
+	if err != nil {
+		fmt.Printf("error selecting device: %v\n", err)
+		status, message := providerError(w, err, http.StatusBadRequest, "Could not play on this device")
+		w.WriteHeader(status)
+		component := templates.SearchError(message)
+		component.Render(r.Context(), w)
+		return
+	}
+
+	devices, err := game.GetDevices(r.Context())
+	if err != nil {
+		fmt.Printf("error getting devices: %v\n", err)
+		devices = []spotify_api.DeviceData{}
+	}
+	component := templates.DevicePicker(devices, game.DeviceId, "")
+	component.Render(r.Context(), w)
+
+	// the song now plays on the device, show the player if it was not yet
+	if _, ok := game.MusicPlayer.CurrentSong(); ok {
+		mp := templates.MusicPlayerUpdate(game)
+		mp.Render(r.Context(), w)
+	}
+}
+
+// renderNoActiveDevice answers a playback error caused by no device playing
+// with the device picker, offering to transfer playback to one of them
+func renderNoActiveDevice(w http.ResponseWriter, r *http.Request, game *service.GameService, err error) bool {
+	if !spotify_api.IsNoActiveDevice(err) {
+		return false
+	}
+
+	devices, err := game.GetDevices(r.Context())
+	if err != nil {
+		fmt.Printf("error getting devices: %v\n", err)
+		devices = []spotify_api.DeviceData{}
+	}
+
+	w.Header().Set("HX-Retarget", "#device-picker-content")
+	w.Header().Set("HX-Reswap", "innerHTML")
+	component := templates.DevicePicker(devices, game.DeviceId, "No Spotify device is playing, pick one to transfer playback to")
+	component.Render(r.Context(), w)
+	return true
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/FerNunez/NameThatSong/internal/manager"
 	"github.com/FerNunez/NameThatSong/internal/templates"
@@ -30,7 +31,7 @@ func (h *PostGuessTrack) ServeHttp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = game.UserGuess(guess)
+	_, err = game.UserGuess(r.Context(), guess)
 	if err != nil {
 		http.Error(w, "Guess user error", http.StatusBadRequest)
 		return
@@ -39,3 +40,35 @@ func (h *PostGuessTrack) ServeHttp(w http.ResponseWriter, r *http.Request) {
 	mp := templates.MusicPlayer(game)
 	mp.Render(r.Context(), w)
 }
+
+type PostChooseTrack struct {
+	gm *manager.GameManager
+}
+
+func NewPostChooseTrack(gm *manager.GameManager) *PostChooseTrack {
+	return &PostChooseTrack{gm}
+}
+
+func (h *PostChooseTrack) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	choice, err := strconv.Atoi(r.FormValue("choice"))
+	if err != nil {
+		http.Error(w, "Choice is required", http.StatusBadRequest)
+		return
+	}
+
+	_, err = game.UserChoose(r.Context(), choice)
+	if err != nil {
+		http.Error(w, "Choose user error", http.StatusBadRequest)
+		return
+	}
+
+	mp := templates.MusicPlayer(game)
+	mp.Render(r.Context(), w)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/FerNunez/NameThatSong/internal/manager"
+	"github.com/FerNunez/NameThatSong/internal/templates"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type GetHistory struct {
+	gm *manager.GameManager
+}
+
+func NewGetHistory(gm *manager.GameManager) *GetHistory {
+	return &GetHistory{gm}
+}
+
+// ServeHttp lists the games the user played
+func (h *GetHistory) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v\n", err)
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	games, err := game.History(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error reading game history: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	component := templates.HistoryPage(games)
+	layout := templates.Layout(component, "Game history")
+	layout.Render(r.Context(), w)
+}
+
+// /////////////////////////////////////
+type GetHistoryGame struct {
+	gm *manager.GameManager
+}
+
+func NewGetHistoryGame(gm *manager.GameManager) *GetHistoryGame {
+	return &GetHistoryGame{gm}
+}
+
+// ServeHttp shows the rounds and guesses of one of the user's games
+func (h *GetHistoryGame) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v\n", err)
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	gameId, err := uuid.Parse(chi.URLParam(r, "gameId"))
+	if err != nil {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+
+	record, rounds, err := game.HistoryGame(r.Context(), gameId)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error reading game: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	component := templates.HistoryGamePage(record, rounds)
+	layout := templates.Layout(component, "Game history")
+	layout.Render(r.Context(), w)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/FerNunez/NameThatSong/internal/manager"
+	"github.com/go-chi/chi/v5"
+)
+
+type GetLibraryStream struct {
+	gm *manager.GameManager
+}
+
+func NewGetLibraryStream(gm *manager.GameManager) *GetLibraryStream {
+	return &GetLibraryStream{gm}
+}
+
+// ServeHttp streams the audio file of the current song, with range support.
+// Only the part of the file that may be heard is served, ranges past it are
+// rejected
+func (h *GetLibraryStream) ServeHttp(w http.ResponseWriter, r *http.Request) {
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v\n", err)
+		http.Error(w, "No game for user", http.StatusUnauthorized)
+		return
+	}
+
+	path, limit, err := game.CurrentTrackStream()
+	if err != nil {
+		http.Error(w, "No track to stream", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if limit == 0 {
+		http.ServeFile(w, r, path)
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		http.Error(w, "No track to stream", http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, "No track to stream", http.StatusNotFound)
+		return
+	}
+	http.ServeContent(w, r, info.Name(), info.ModTime(), io.NewSectionReader(f, 0, limit))
+}
+
+// /////////////////////////////////////
+type GetLibraryCover struct {
+	gm *manager.GameManager
+}
+
+func NewGetLibraryCover(gm *manager.GameManager) *GetLibraryCover {
+	return &GetLibraryCover{gm}
+}
+
+func (h *GetLibraryCover) ServeHttp(w http.ResponseWriter, r *http.Request) {
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v\n", err)
+		http.Error(w, "No game for user", http.StatusUnauthorized)
+		return
+	}
+
+	data, mime, err := game.AlbumCover(chi.URLParam(r, "albumId"))
+	if err != nil {
+		http.Error(w, "Cover not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", mime)
+	w.Header().Set("Cache-Control", "max-age=86400")
+	w.Write(data)
+}
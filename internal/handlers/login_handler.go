@@ -79,7 +79,7 @@ func (h PostLoginHandler) ServeHttp(w http.ResponseWriter, r *http.Request) {
 	// Login without game
 	if _, err := h.GameManager.GetGame(r.Context()); err != nil {
 		fmt.Println("recreating game for user: ", dbUser.ID.String())
-		h.GameManager.CreateGame(dbUser.ID, h.SpotifyTokenStore)
+		h.GameManager.CreateGame(r.Context(), dbUser.ID, h.SpotifyTokenStore)
 	}
 
 	ttl := time.Duration(24 * time.Hour)
@@ -24,8 +24,11 @@ func (h *PostPlayPause) ServeHttp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = game.SpotifyApi.PausePlayback(game.SpotifyToken.AccessToken)
+	err = game.PausePlayback(r.Context())
 	if err != nil {
+		if renderNoActiveDevice(w, r, game, err) {
+			return
+		}
 		http.Error(w, fmt.Sprintf("Error play game: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -52,6 +55,117 @@ func (h *PostSkip) ServeHttp(w http.ResponseWriter, r *http.Request) {
 
 	err = game.SkipSong(r.Context())
 	if err != nil {
+		if renderNoActiveDevice(w, r, game, err) {
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error skipping song: %v", err), http.StatusInternalServerError)
+		return
+	}
+	mp := templates.MusicPlayer(game)
+	mp.Render(r.Context(), w)
+}
+
+// /////////////////////////////////////
+type PostReplay struct {
+	gm *manager.GameManager
+}
+
+func NewPostReplay(gm *manager.GameManager) *PostReplay {
+	return &PostReplay{gm}
+}
+
+func (h *PostReplay) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	err = game.ReplaySong(r.Context())
+	if err != nil {
+		if renderNoActiveDevice(w, r, game, err) {
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error replaying song: %v", err), http.StatusInternalServerError)
+		return
+	}
+	mp := templates.MusicPlayer(game)
+	mp.Render(r.Context(), w)
+}
+
+// /////////////////////////////////////
+type PostReveal struct {
+	gm *manager.GameManager
+}
+
+func NewPostReveal(gm *manager.GameManager) *PostReveal {
+	return &PostReveal{gm}
+}
+
+func (h *PostReveal) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	game.RevealSong()
+	mp := templates.MusicPlayer(game)
+	mp.Render(r.Context(), w)
+}
+
+// /////////////////////////////////////
+type PostHint struct {
+	gm *manager.GameManager
+}
+
+func NewPostHint(gm *manager.GameManager) *PostHint {
+	return &PostHint{gm}
+}
+
+func (h *PostHint) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	hint := r.FormValue("hint")
+	err = game.UseHint(hint)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid hint: %v", hint), http.StatusBadRequest)
+		return
+	}
+
+	mp := templates.MusicPlayer(game)
+	mp.Render(r.Context(), w)
+}
+
+// /////////////////////////////////////
+type PostDeadline struct {
+	gm *manager.GameManager
+}
+
+func NewPostDeadline(gm *manager.GameManager) *PostDeadline {
+	return &PostDeadline{gm}
+}
+
+// ServeHttp is asked by the page once the survival deadline is due, the
+// server checks it on its own clock
+func (h *PostDeadline) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	err = game.CheckDeadline(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error checking deadline: %v", err), http.StatusInternalServerError)
 		return
 	}
 	mp := templates.MusicPlayer(game)
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/FerNunez/NameThatSong/internal/manager"
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+	"github.com/FerNunez/NameThatSong/internal/templates"
+)
+
+type GetPlaylists struct {
+	gm *manager.GameManager
+}
+
+func NewGetPlaylists(gm *manager.GameManager) *GetPlaylists {
+	return &GetPlaylists{gm}
+}
+
+// ServeHttp lists the user's playlists, or the single playlist pasted as a
+// URL, URI or ID in the playlist query
+func (h *GetPlaylists) ServeHttp(w http.ResponseWriter, r *http.Request) {
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v\n", err)
+		return
+	}
+
+	var playlists []spotify_api.PlaylistData
+	ref := r.URL.Query().Get("playlist")
+	if ref == "" {
+		playlists, err = game.GetUserPlaylists(r.Context())
+	} else {
+		var playlist spotify_api.PlaylistData
+		playlist, err = game.FindPlaylist(r.Context(), ref)
+		playlists = []spotify_api.PlaylistData{playlist}
+	}
+	if err != nil {
+		fmt.Printf("error getting playlists: %v\n", err)
+		fallbackMessage := "Could not retrieve playlists"
+		if errors.Is(err, spotify_api.ErrInvalidPlaylist) {
+			fallbackMessage = "Paste a Spotify playlist link or URI"
+		}
+		status, message := providerError(w, err, http.StatusBadRequest, fallbackMessage)
+		w.WriteHeader(status)
+		component := templates.SearchError(message)
+		component.Render(r.Context(), w)
+		return
+	}
+
+	component := templates.PlaylistDropdown(playlists, game.PlaylistSelection)
+	component.Render(r.Context(), w)
+}
+
+//////////////////
+
+type PostSelectPlaylist struct {
+	gm *manager.GameManager
+}
+
+func NewPostSelectPlaylist(gm *manager.GameManager) *PostSelectPlaylist {
+	return &PostSelectPlaylist{gm}
+}
+
+func (h *PostSelectPlaylist) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	playlistID := r.Form.Get("playlistID")
+	if playlistID == "" {
+		http.Error(w, "Playlist ID is required", http.StatusBadRequest)
+		return
+	}
+
+	playlist, ok := game.Cache.PlaylistMap[playlistID]
+	if !ok {
+		http.Error(w, "Unknown playlist", http.StatusBadRequest)
+		return
+	}
+
+	// Toggle playlist selection
+	toggle := game.TogglePlaylistSelection(playlistID)
+
+	component := templates.PlaylistCard(playlist, toggle)
+	component.Render(r.Context(), w)
+}
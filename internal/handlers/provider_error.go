@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+)
+
+// providerError picks the status and message shown to the player for an
+// error of the song provider, falling back to the given ones for other errors
+func providerError(w http.ResponseWriter, err error, fallbackStatus int, fallbackMessage string) (int, string) {
+	var spotifyErr *spotify_api.SpotifyError
+	if !errors.As(err, &spotifyErr) {
+		return fallbackStatus, fallbackMessage
+	}
+
+	if spotifyErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(spotifyErr.RetryAfter.Seconds())))
+	}
+	return spotifyErr.HTTPStatus(), spotifyErr.UserMessage()
+}
@@ -64,7 +64,7 @@ func (h PostRegisterHandler) ServeHttp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.GameManager.CreateGame(dbUser.ID, h.SpotifyTokenStore)
+	err = h.GameManager.CreateGame(r.Context(), dbUser.ID, h.SpotifyTokenStore)
 	if err != nil {
 		fmt.Println("could not create game", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 
 	"github.com/FerNunez/NameThatSong/internal/manager"
@@ -34,8 +35,15 @@ func (h *GetSearchArtists) ServeHttp(w http.ResponseWriter, r *http.Request) {
 	}
 
 	artists, err := game.SearchArtists(r.Context(), query)
-	if err != nil || len(artists) == 0 {
-		// TODO: SEND ERROR TO REQUEST
+	if err != nil {
+		fmt.Printf("error searching artists: %v\n", err)
+		status, message := providerError(w, err, http.StatusBadRequest, "Could not search artists")
+		w.WriteHeader(status)
+		component := templates.SearchError(message)
+		component.Render(r.Context(), w)
+		return
+	}
+	if len(artists) == 0 {
 		component := templates.SearchResults([]spotify_api.ArtistData{})
 		component.Render(r.Context(), w)
 		return
@@ -75,14 +83,34 @@ func (h *GetArtistAlbums) ServeHttp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	albums, err := game.GetArtistsAlbum(r.Context(), artistID)
-	//albums, err := game.SpotifyApi.FetchAlbumByArtistID(artistID)
+	groups := albumGroups(r)
+	albums, err := game.GetArtistsAlbum(r.Context(), artistID, groups)
+	//albums, err := game.SongProvider.FetchAlbumByArtistID(artistID)
 	if err != nil {
-		http.Error(w, "Cant retrieve Artist ID albums", http.StatusBadRequest)
-		fmt.Println("no album")
+		fmt.Printf("error getting albums: %v\n", err)
+		status, message := providerError(w, err, http.StatusBadRequest, "Cant retrieve Artist ID albums")
+		w.WriteHeader(status)
+		component := templates.SearchError(message)
+		component.Render(r.Context(), w)
 		return
 	}
 
-	component := templates.AlbumDropdown(albums, game.AlbumSelection, artistID)
+	component := templates.AlbumDropdown(albums, game.AlbumSelection, artistID, groups)
 	component.Render(r.Context(), w)
 }
+
+// albumGroups reads the album groups ticked in the dropdown, albums only by default
+func albumGroups(r *http.Request) []string {
+	selected := r.URL.Query()["group"]
+
+	groups := make([]string, 0, len(selected))
+	for _, group := range spotify_api.AlbumGroups {
+		if slices.Contains(selected, group) {
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) == 0 {
+		return []string{spotify_api.AlbumGroupAlbum}
+	}
+	return groups
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/FerNunez/NameThatSong/internal/manager"
+	"github.com/FerNunez/NameThatSong/internal/service"
 	"github.com/FerNunez/NameThatSong/internal/templates"
 )
 
@@ -54,6 +55,47 @@ func (h *PostSelectAlbum) ServeHttp(w http.ResponseWriter, r *http.Request) {
 
 //////////////////
 
+type PostSelectSource struct {
+	gm *manager.GameManager
+}
+
+func NewPostSelectSource(gm *manager.GameManager) *PostSelectSource {
+	return &PostSelectSource{gm}
+}
+
+func (h *PostSelectSource) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	sourceID := r.Form.Get("sourceID")
+	source, ok := service.FindPersonalSource(sourceID)
+	if !ok {
+		http.Error(w, "Unknown song source", http.StatusBadRequest)
+		return
+	}
+
+	// Toggle source selection
+	toggle, err := game.ToggleSourceSelection(sourceID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	component := templates.SourceChip(source, toggle)
+	component.Render(r.Context(), w)
+}
+
+//////////////////
+
 type PostClearQueue struct {
 	gm *manager.GameManager
 }
@@ -69,7 +111,7 @@ func (h *PostClearQueue) ServeHttp(w http.ResponseWriter, r *http.Request) {
 		fmt.Printf("error getting game : %v", err)
 		return
 	}
-	game.ClearQueue()
+	game.ClearQueue(r.Context())
 	mp := templates.MusicPlayer(game)
 	mp.Render(r.Context(), w)
 }
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/FerNunez/NameThatSong/internal/manager"
+	"github.com/FerNunez/NameThatSong/internal/service"
 	"github.com/FerNunez/NameThatSong/internal/templates"
 )
 
@@ -24,9 +28,23 @@ func (h *PostStartGame) ServeHttp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Start the game
+	settings, err := settingsFromForm(r, game.Settings)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	game.Settings = settings
+
+	// Start the game, the settings are validated first
 	err = game.StartGame(r.Context())
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidSettings) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if renderNoActiveDevice(w, r, game, err) {
+			return
+		}
 		http.Error(w, fmt.Sprintf("Error starting game: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -34,3 +52,69 @@ func (h *PostStartGame) ServeHttp(w http.ResponseWriter, r *http.Request) {
 	mp := templates.MusicPlayer(game)
 	mp.Render(r.Context(), w)
 }
+
+// settingsFromForm reads the settings panel over the settings, the values
+// not sent are kept. Checkboxes are only sent when ticked
+func settingsFromForm(r *http.Request, settings service.GameSettings) (service.GameSettings, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return settings, err
+	}
+
+	numbers := []struct {
+		name  string
+		value *int
+	}{
+		{"rounds", &settings.Rounds},
+		{"lives", &settings.Lives},
+		{"skip-penalty", &settings.Scoring.SkipPenalty},
+	}
+	for _, number := range numbers {
+		if text := r.FormValue(number.name); text != "" {
+			*number.value, err = strconv.Atoi(text)
+			if err != nil {
+				return settings, fmt.Errorf("%w: %v must be a number", service.ErrInvalidSettings, number.name)
+			}
+		}
+	}
+
+	// durations in seconds
+	seconds := []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"clip-length", &settings.ClipLength},
+		{"time-decay", &settings.Scoring.Decay},
+		{"round-time", &settings.RoundTime},
+	}
+	for _, duration := range seconds {
+		if text := r.FormValue(duration.name); text != "" {
+			n, err := strconv.Atoi(text)
+			if err != nil {
+				return settings, fmt.Errorf("%w: %v must be a number of seconds", service.ErrInvalidSettings, duration.name)
+			}
+			*duration.value = time.Duration(n) * time.Second
+		}
+	}
+
+	if text := r.FormValue("streak-bonus"); text != "" {
+		percent, err := strconv.Atoi(text)
+		if err != nil {
+			return settings, fmt.Errorf("%w: streak-bonus must be a percentage", service.ErrInvalidSettings)
+		}
+		settings.Scoring.StreakBonus = float64(percent) / 100
+	}
+
+	if strictness := r.FormValue("strictness"); strictness != "" {
+		settings.Strictness = strictness
+	}
+	if language := r.FormValue("language"); language != "" {
+		settings.Language = language
+	}
+
+	settings.Heardle = r.FormValue("heardle") != ""
+	settings.MultipleChoice = r.FormValue("multiple-choice") != ""
+	settings.Hints = r.FormValue("hints") != ""
+	settings.Fields = r.Form["fields"]
+	return settings, nil
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/FerNunez/NameThatSong/internal/manager"
+	"github.com/FerNunez/NameThatSong/internal/templates"
+)
+
+type GetSummary struct {
+	gm *manager.GameManager
+}
+
+func NewGetSummary(gm *manager.GameManager) *GetSummary {
+	return &GetSummary{gm}
+}
+
+func (h *GetSummary) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	component := templates.SummaryPage(game)
+	layout := templates.Layout(component, "Game summary")
+	layout.Render(r.Context(), w)
+}
+
+// /////////////////////////////////////
+type PostPlayAgain struct {
+	gm *manager.GameManager
+}
+
+func NewPostPlayAgain(gm *manager.GameManager) *PostPlayAgain {
+	return &PostPlayAgain{gm}
+}
+
+// ServeHttp restarts the game on the same songs. The game over panel swaps
+// the player in, the summary page goes back to the game
+func (h *PostPlayAgain) ServeHttp(w http.ResponseWriter, r *http.Request) {
+
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v", err)
+		return
+	}
+
+	err = game.PlayAgain(r.Context())
+	if err != nil {
+		if renderNoActiveDevice(w, r, game, err) {
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error playing again: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "" {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	mp := templates.MusicPlayer(game)
+	mp.Render(r.Context(), w)
+}
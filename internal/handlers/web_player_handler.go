@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/FerNunez/NameThatSong/internal/manager"
+)
+
+type GetPlayerToken struct {
+	gm *manager.GameManager
+}
+
+func NewGetPlayerToken(gm *manager.GameManager) *GetPlayerToken {
+	return &GetPlayerToken{gm}
+}
+
+// ServeHttp hands the Web Playback SDK the access token it plays with
+func (h *GetPlayerToken) ServeHttp(w http.ResponseWriter, r *http.Request) {
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v\n", err)
+		http.Error(w, "No game", http.StatusUnauthorized)
+		return
+	}
+
+	accessToken, err := game.AccessToken(r.Context())
+	if err != nil {
+		fmt.Printf("error getting access token: %v\n", err)
+		http.Error(w, "Spotify is not connected", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(struct {
+		AccessToken string `json:"access_token"`
+	}{accessToken})
+}
+
+//////////////////
+
+type PostPlayerDevice struct {
+	gm *manager.GameManager
+}
+
+func NewPostPlayerDevice(gm *manager.GameManager) *PostPlayerDevice {
+	return &PostPlayerDevice{gm}
+}
+
+// ServeHttp registers the Web Playback SDK device of the page
+func (h *PostPlayerDevice) ServeHttp(w http.ResponseWriter, r *http.Request) {
+	game, err := h.gm.GetGame(r.Context())
+	if err != nil {
+		fmt.Printf("error getting game : %v\n", err)
+		http.Error(w, "No game", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	deviceID := r.Form.Get("device_id")
+	if deviceID == "" {
+		http.Error(w, "Device ID is required", http.StatusBadRequest)
+		return
+	}
+
+	err = game.RegisterBrowserDevice(r.Context(), deviceID)
+	if err != nil {
+		fmt.Printf("error registering browser device: %v\n", err)
+		status, message := providerError(w, err, http.StatusBadRequest, "Could not play in the browser")
+		http.Error(w, message, status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,162 @@
+package library
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	flacStreamInfo    = 0
+	flacVorbisComment = 4
+	flacPicture       = 6
+)
+
+// readFLAC walks the FLAC metadata blocks for the stream info, the Vorbis
+// comments and the front cover
+func readFLAC(r io.Reader) (Tags, error) {
+	var tags Tags
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return Tags{}, err
+	}
+	if string(magic) != "fLaC" {
+		return Tags{}, errors.New("not a flac file")
+	}
+
+	header := make([]byte, 4)
+	offset := int64(len(magic))
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return Tags{}, err
+		}
+		last := header[0]&0x80 != 0
+		blockType := header[0] & 0x7f
+		length := int(header[1])<<16 | int(header[2])<<8 | int(header[3])
+
+		block := make([]byte, length)
+		if _, err := io.ReadFull(r, block); err != nil {
+			return Tags{}, err
+		}
+		offset += int64(len(header) + length)
+
+		switch blockType {
+		case flacStreamInfo:
+			tags.DurationMs = flacDuration(block)
+		case flacVorbisComment:
+			readVorbisComments(&tags, block)
+		case flacPicture:
+			if tags.Picture == nil {
+				tags.Picture, tags.PictureMIME = parseFLACPicture(block)
+			}
+		}
+
+		if last {
+			tags.AudioOffset = offset
+			return tags, nil
+		}
+	}
+}
+
+func flacDuration(block []byte) int {
+	if len(block) < 18 {
+		return 0
+	}
+	// 20 bits sample rate, 3 bits channels, 5 bits sample size, 36 bits samples
+	packed := binary.BigEndian.Uint64(block[10:18])
+	sampleRate := packed >> 44
+	totalSamples := packed & (1<<36 - 1)
+	if sampleRate == 0 {
+		return 0
+	}
+	return int(totalSamples * 1000 / sampleRate)
+}
+
+// readVorbisComments parses a little-endian Vorbis comment structure
+func readVorbisComments(tags *Tags, b []byte) {
+	next := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		n := int(binary.LittleEndian.Uint32(b))
+		if n < 0 || 4+n > len(b) {
+			return nil, false
+		}
+		field := b[4 : 4+n]
+		b = b[4+n:]
+		return field, true
+	}
+
+	// vendor string
+	if _, ok := next(); !ok || len(b) < 4 {
+		return
+	}
+	count := int(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	for i := 0; i < count; i++ {
+		comment, ok := next()
+		if !ok {
+			return
+		}
+		tags.setVorbisComment(string(comment))
+	}
+}
+
+// parseFLACPicture reads a big-endian FLAC PICTURE block
+func parseFLACPicture(b []byte) ([]byte, string) {
+	next := func(n int) ([]byte, bool) {
+		if n < 0 || n > len(b) {
+			return nil, false
+		}
+		field := b[:n]
+		b = b[n:]
+		return field, true
+	}
+	u32 := func() (int, bool) {
+		field, ok := next(4)
+		if !ok {
+			return 0, false
+		}
+		return int(binary.BigEndian.Uint32(field)), true
+	}
+
+	// picture type
+	if _, ok := u32(); !ok {
+		return nil, ""
+	}
+	mimeLen, ok := u32()
+	if !ok {
+		return nil, ""
+	}
+	mime, ok := next(mimeLen)
+	if !ok {
+		return nil, ""
+	}
+	descLen, ok := u32()
+	if !ok {
+		return nil, ""
+	}
+	// description, width, height, depth and colors
+	if _, ok := next(descLen + 16); !ok {
+		return nil, ""
+	}
+	dataLen, ok := u32()
+	if !ok {
+		return nil, ""
+	}
+	data, ok := next(dataLen)
+	if !ok {
+		return nil, ""
+	}
+	return data, string(mime)
+}
+
+func (t *Tags) setBase64Picture(value string) {
+	block, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return
+	}
+	t.Picture, t.PictureMIME = parseFLACPicture(block)
+}
@@ -0,0 +1,109 @@
+package library_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/library"
+)
+
+// flacBlock builds a metadata block header and its data
+func flacBlock(blockType byte, last bool, data []byte) []byte {
+	if last {
+		blockType |= 0x80
+	}
+	return append([]byte{blockType, byte(len(data) >> 16), byte(len(data) >> 8), byte(len(data))}, data...)
+}
+
+// streamInfo is a STREAMINFO block of that many samples at that rate
+func streamInfo(sampleRate, samples uint64) []byte {
+	block := make([]byte, 34)
+	// 2 channels, 16 bits per sample
+	binary.BigEndian.PutUint64(block[10:18], sampleRate<<44|1<<41|15<<36|samples)
+	return block
+}
+
+func flacFile(blocks ...[]byte) []byte {
+	data := []byte("fLaC")
+	for _, block := range blocks {
+		data = append(data, block...)
+	}
+	return data
+}
+
+func TestReadFLAC(t *testing.T) {
+	cover := []byte("\x89PNG")
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected library.Tags
+	}{
+		{
+			"comments and picture",
+			flacFile(
+				flacBlock(0, false, streamInfo(44100, 441000)),
+				flacBlock(4, false, vorbisComments("TITLE=Hey Jude", "artist=The Beatles", "ALBUM=Past Masters", "DATE=1968-08-26", "TRACKNUMBER=3/12", "DISCNUMBER=2")),
+				flacBlock(6, true, flacPicture("image/png", cover)),
+			),
+			library.Tags{Title: "Hey Jude", Artist: "The Beatles", Album: "Past Masters", Year: "1968", TrackNumber: 3, DiscNumber: 2, DurationMs: 10000, Picture: cover, PictureMIME: "image/png"},
+		},
+		{
+			"first artist kept",
+			flacFile(flacBlock(4, true, vorbisComments("ARTIST=Queen", "ARTIST=David Bowie", "TITLE=Under Pressure"))),
+			library.Tags{Title: "Under Pressure", Artist: "Queen", Album: "Unknown Album"},
+		},
+		{
+			"comment past the block",
+			flacFile(flacBlock(4, true, append(vorbisComments("TITLE=Hey Jude"), 0xff, 0xff, 0xff, 0x0f))),
+			library.Tags{Title: "Hey Jude", Artist: "Unknown Artist", Album: "Unknown Album"},
+		},
+		{
+			"comment count past the block",
+			flacFile(flacBlock(4, true, []byte{0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff})),
+			library.Tags{Title: "song", Artist: "Unknown Artist", Album: "Unknown Album"},
+		},
+		{
+			"picture length past the block",
+			flacFile(flacBlock(6, true, flacPicture("image/png", cover)[:20])),
+			library.Tags{Title: "song", Artist: "Unknown Artist", Album: "Unknown Album"},
+		},
+		{
+			"short stream info",
+			flacFile(flacBlock(0, true, make([]byte, 10))),
+			library.Tags{Title: "song", Artist: "Unknown Artist", Album: "Unknown Album"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tags, err := readFixture(t, "song.flac", tc.data)
+			if err != nil {
+				t.Fatalf("ReadTags() = %v", err)
+			}
+			checkTags(t, tags, tc.expected)
+		})
+	}
+}
+
+func TestReadFLACMalformed(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty file", nil},
+		{"not flac", []byte("ID3\x03\x00\x00\x00\x00\x00\x00")},
+		{"no last block", flacFile(flacBlock(0, false, streamInfo(44100, 441000)))},
+		{"truncated block header", flacFile([]byte{0x84, 0})},
+		{"block past the file", flacFile(flacBlock(4, true, vorbisComments("TITLE=Hey Jude"))[:10])},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readFixture(t, "song.flac", tc.data)
+			if err == nil {
+				t.Errorf("ReadTags() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package library
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+)
+
+// Track is an audio file of the library
+type Track struct {
+	spotify_api.TrackData
+	Path     string
+	AlbumId  string
+	ArtistId string
+	// AudioOffset is the byte of the file the audio starts at
+	AudioOffset int64
+}
+
+type cover struct {
+	data []byte
+	mime string
+}
+
+// Library is an index of the audio files found under a directory, grouped
+// the same way as the Spotify catalog: artist -> album -> track
+type Library struct {
+	Dir            string
+	Artists        map[string]spotify_api.ArtistData
+	Albums         map[string]spotify_api.AlbumData
+	Tracks         map[string]Track
+	ArtistToAlbums map[string][]string
+	AlbumToTracks  map[string][]string
+	covers         map[string]cover
+	// Skipped are the audio files whose tags couldn't be read, with why
+	Skipped map[string]error
+}
+
+// NewLibrary scans dir recursively for MP3, FLAC and OGG files
+func NewLibrary(dir string) (*Library, error) {
+	l := &Library{
+		Dir:            dir,
+		Artists:        make(map[string]spotify_api.ArtistData),
+		Albums:         make(map[string]spotify_api.AlbumData),
+		Tracks:         make(map[string]Track),
+		ArtistToAlbums: make(map[string][]string),
+		AlbumToTracks:  make(map[string][]string),
+		covers:         make(map[string]cover),
+		Skipped:        make(map[string]error),
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !supportedExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+
+		tags, err := ReadTags(path)
+		if err != nil {
+			// skip broken files rather than failing the whole scan
+			l.Skipped[path] = err
+			return nil
+		}
+		l.add(path, tags)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning music library %v: %w", dir, err)
+	}
+
+	l.sort()
+	log.Printf("Music library %v: %d artists, %d albums, %d tracks, %d files skipped", dir, len(l.Artists), len(l.Albums), len(l.Tracks), len(l.Skipped))
+	return l, nil
+}
+
+func (l *Library) add(path string, tags Tags) {
+	artistId := hashId(strings.ToLower(tags.Artist))
+	albumId := hashId(strings.ToLower(tags.Artist) + "\x00" + strings.ToLower(tags.Album))
+	trackId := hashId(path)
+
+	if _, ok := l.Artists[artistId]; !ok {
+		l.Artists[artistId] = spotify_api.ArtistData{
+			Id:   artistId,
+			Name: tags.Artist,
+		}
+	}
+	artist := l.Artists[artistId]
+	artist.Popularity++
+	l.Artists[artistId] = artist
+
+	album, ok := l.Albums[albumId]
+	if !ok {
+		album = spotify_api.AlbumData{
+			AlbumType:   spotify_api.AlbumGroupAlbum,
+			AlbumGroup:  spotify_api.AlbumGroupAlbum,
+			ID:          albumId,
+			Name:        tags.Album,
+			ReleaseDate: tags.Year,
+		}
+		l.ArtistToAlbums[artistId] = append(l.ArtistToAlbums[artistId], albumId)
+	}
+	if album.ReleaseDate == "" {
+		album.ReleaseDate = tags.Year
+	}
+	album.TotalTracks++
+	if _, ok := l.covers[albumId]; !ok && tags.Picture != nil {
+		l.covers[albumId] = cover{data: tags.Picture, mime: tags.PictureMIME}
+		album.ImagesURL = CoverURL(albumId)
+		if artist.ImageUrl == "" {
+			artist.ImageUrl = album.ImagesURL
+			l.Artists[artistId] = artist
+		}
+	}
+	l.Albums[albumId] = album
+
+	l.Tracks[trackId] = Track{
+		TrackData: spotify_api.TrackData{
+			DiscNumber:  tags.DiscNumber,
+			DurationMs:  tags.DurationMs,
+			ID:          trackId,
+			Name:        tags.Title,
+			TrackNumber: tags.TrackNumber,
+			Artists:     []string{tags.Artist},
+		},
+		Path:        path,
+		AlbumId:     albumId,
+		ArtistId:    artistId,
+		AudioOffset: tags.AudioOffset,
+	}
+	l.AlbumToTracks[albumId] = append(l.AlbumToTracks[albumId], trackId)
+}
+
+// sort orders albums by release year and tracks by disc and track number
+func (l *Library) sort() {
+	for _, albumIds := range l.ArtistToAlbums {
+		sort.SliceStable(albumIds, func(i, j int) bool {
+			return l.Albums[albumIds[i]].ReleaseDate < l.Albums[albumIds[j]].ReleaseDate
+		})
+	}
+	for _, trackIds := range l.AlbumToTracks {
+		sort.SliceStable(trackIds, func(i, j int) bool {
+			a, b := l.Tracks[trackIds[i]], l.Tracks[trackIds[j]]
+			if a.DiscNumber != b.DiscNumber {
+				return a.DiscNumber < b.DiscNumber
+			}
+			if a.TrackNumber != b.TrackNumber {
+				return a.TrackNumber < b.TrackNumber
+			}
+			return a.Path < b.Path
+		})
+	}
+}
+
+// Cover returns the embedded front cover of an album
+func (l *Library) Cover(albumId string) ([]byte, string, bool) {
+	c, ok := l.covers[albumId]
+	return c.data, c.mime, ok
+}
+
+// CoverURL is the route serving the cover of an album
+func CoverURL(albumId string) string {
+	return "/library/cover/" + albumId
+}
+
+func hashId(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
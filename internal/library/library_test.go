@@ -0,0 +1,34 @@
+package library_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/library"
+)
+
+func TestNewLibrarySkipped(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"good.mp3":   append(id3v2(3, id3Frame(3, "TIT2", latin1("Hey Jude"))), mpegFrames()...),
+		"broken.mp3": []byte("ID3\x03\x00"),
+		"notes.txt":  []byte("not audio"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lib, err := library.NewLibrary(dir)
+	if err != nil {
+		t.Fatalf("NewLibrary() = %v", err)
+	}
+	if len(lib.Tracks) != 1 {
+		t.Errorf("%d tracks, want 1", len(lib.Tracks))
+	}
+	if len(lib.Skipped) != 1 || lib.Skipped[filepath.Join(dir, "broken.mp3")] == nil {
+		t.Errorf("Skipped = %v, want broken.mp3", lib.Skipped)
+	}
+}
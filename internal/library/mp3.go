@@ -0,0 +1,317 @@
+package library
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// readMP3 reads the ID3v2 tag (falling back to ID3v1) and computes the
+// duration from the first MPEG frame
+func readMP3(r io.ReadSeeker, size int64) (Tags, error) {
+	var tags Tags
+
+	header := make([]byte, 10)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Tags{}, err
+	}
+
+	audioStart := int64(0)
+	if string(header[:3]) == "ID3" {
+		tagSize := int64(syncsafe(header[6:10]))
+		if 10+tagSize > size {
+			return Tags{}, errors.New("id3 tag larger than the file")
+		}
+		body := make([]byte, tagSize)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return Tags{}, err
+		}
+		readID3v2(&tags, header[3], header[5], body)
+		audioStart = 10 + tagSize
+		// footer present
+		if header[5]&0x10 != 0 {
+			audioStart += 10
+		}
+	} else if size >= 128 {
+		readID3v1(&tags, r, size)
+	}
+
+	tags.AudioOffset = audioStart
+	if tags.DurationMs == 0 {
+		if _, err := r.Seek(audioStart, io.SeekStart); err != nil {
+			return Tags{}, err
+		}
+		tags.DurationMs = mp3Duration(r, size-audioStart)
+	}
+	return tags, nil
+}
+
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7f)<<21 | int(b[1]&0x7f)<<14 | int(b[2]&0x7f)<<7 | int(b[3]&0x7f)
+}
+
+func readID3v2(tags *Tags, version byte, flags byte, body []byte) {
+	// extended header
+	if flags&0x40 != 0 && len(body) >= 4 {
+		switch version {
+		case 3:
+			body = body[min(len(body), 4+int(binary.BigEndian.Uint32(body))):]
+		case 4:
+			body = body[min(len(body), syncsafe(body)):]
+		}
+	}
+
+	idSize, headerSize := 4, 10
+	if version == 2 {
+		idSize, headerSize = 3, 6
+	}
+
+	for len(body) >= headerSize && body[0] != 0 {
+		id := string(body[:idSize])
+		var frameSize int
+		switch version {
+		case 2:
+			frameSize = int(body[3])<<16 | int(body[4])<<8 | int(body[5])
+		case 3:
+			frameSize = int(binary.BigEndian.Uint32(body[4:8]))
+		default:
+			frameSize = syncsafe(body[4:8])
+		}
+		if frameSize <= 0 || headerSize+frameSize > len(body) {
+			return
+		}
+		data := body[headerSize : headerSize+frameSize]
+		body = body[headerSize+frameSize:]
+
+		switch id {
+		case "TIT2", "TT2":
+			tags.Title = id3Text(data)
+		case "TPE1", "TP1":
+			tags.Artist = id3Text(data)
+		case "TALB", "TAL":
+			tags.Album = id3Text(data)
+		case "TYER", "TYE", "TDRC":
+			tags.Year = parseYear(id3Text(data))
+		case "TRCK", "TRK":
+			tags.TrackNumber = parseNumber(id3Text(data))
+		case "TPOS", "TPA":
+			tags.DiscNumber = parseNumber(id3Text(data))
+		case "TLEN", "TLE":
+			if ms, err := strconv.Atoi(id3Text(data)); err == nil {
+				tags.DurationMs = ms
+			}
+		case "APIC":
+			if tags.Picture == nil {
+				tags.Picture, tags.PictureMIME = id3Picture(data, false)
+			}
+		case "PIC":
+			if tags.Picture == nil {
+				tags.Picture, tags.PictureMIME = id3Picture(data, true)
+			}
+		}
+	}
+}
+
+// id3Text decodes a text frame according to its encoding byte
+func id3Text(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	text := decodeID3String(data[0], data[1:])
+	// multiple values are separated by a null character
+	text, _, _ = strings.Cut(text, "\x00")
+	return strings.TrimSpace(text)
+}
+
+func decodeID3String(encoding byte, b []byte) string {
+	switch encoding {
+	case 1, 2:
+		return decodeUTF16(b, encoding == 2)
+	case 3:
+		return string(b)
+	default:
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		return string(runes)
+	}
+}
+
+func decodeUTF16(b []byte, bigEndian bool) string {
+	if len(b) >= 2 {
+		switch {
+		case b[0] == 0xfe && b[1] == 0xff:
+			bigEndian, b = true, b[2:]
+		case b[0] == 0xff && b[1] == 0xfe:
+			bigEndian, b = false, b[2:]
+		}
+	}
+
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		if bigEndian {
+			u = append(u, binary.BigEndian.Uint16(b[i:]))
+		} else {
+			u = append(u, binary.LittleEndian.Uint16(b[i:]))
+		}
+	}
+	return string(utf16.Decode(u))
+}
+
+// id3Picture extracts the image of an APIC (or ID3v2.2 PIC) frame
+func id3Picture(data []byte, v22 bool) ([]byte, string) {
+	if len(data) < 2 {
+		return nil, ""
+	}
+	encoding := data[0]
+	data = data[1:]
+
+	var mime string
+	if v22 {
+		if len(data) < 3 {
+			return nil, ""
+		}
+		mime = "image/" + strings.ToLower(string(data[:3]))
+		if mime == "image/jpg" {
+			mime = "image/jpeg"
+		}
+		data = data[3:]
+	} else {
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			return nil, ""
+		}
+		mime = string(data[:end])
+		data = data[end+1:]
+	}
+
+	// picture type
+	if len(data) < 1 {
+		return nil, ""
+	}
+	data = data[1:]
+
+	// description, null terminated in the frame encoding
+	if encoding == 1 || encoding == 2 {
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				return data[i+2:], mime
+			}
+		}
+		return nil, ""
+	}
+	end := bytes.IndexByte(data, 0)
+	if end < 0 {
+		return nil, ""
+	}
+	return data[end+1:], mime
+}
+
+func readID3v1(tags *Tags, r io.ReadSeeker, size int64) {
+	buf := make([]byte, 128)
+	if _, err := r.Seek(size-128, io.SeekStart); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(r, buf); err != nil || string(buf[:3]) != "TAG" {
+		return
+	}
+
+	field := func(b []byte) string {
+		return strings.TrimSpace(strings.TrimRight(decodeID3String(0, b), "\x00"))
+	}
+	tags.Title = field(buf[3:33])
+	tags.Artist = field(buf[33:63])
+	tags.Album = field(buf[63:93])
+	tags.Year = field(buf[93:97])
+	// ID3v1.1 track number
+	if buf[125] == 0 && buf[126] != 0 {
+		tags.TrackNumber = int(buf[126])
+	}
+}
+
+var (
+	mpeg1Bitrates = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mpeg2Bitrates = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+	sampleRates   = map[byte][3]int{
+		3: {44100, 48000, 32000}, // MPEG 1
+		2: {22050, 24000, 16000}, // MPEG 2
+		0: {11025, 12000, 8000},  // MPEG 2.5
+	}
+)
+
+// mp3Duration estimates the duration in milliseconds of the audio starting at
+// the reader position, using the Xing/VBRI header when present and the
+// bitrate of the first frame otherwise
+func mp3Duration(r io.Reader, audioSize int64) int {
+	buf := make([]byte, 8192)
+	n, _ := io.ReadFull(r, buf)
+	buf = buf[:n]
+
+	frame, err := findMPEGFrame(buf)
+	if err != nil {
+		return 0
+	}
+	h := buf[frame:]
+
+	version := (h[1] >> 3) & 0x03
+	layer := (h[1] >> 1) & 0x03
+	if layer != 1 { // Layer III only
+		return 0
+	}
+	rates, ok := sampleRates[version]
+	rateIndex := (h[2] >> 2) & 0x03
+	if !ok || rateIndex == 3 {
+		return 0
+	}
+	sampleRate := rates[rateIndex]
+	bitrate := mpeg1Bitrates[h[2]>>4]
+	samplesPerFrame := 1152
+	if version != 3 {
+		bitrate = mpeg2Bitrates[h[2]>>4]
+		samplesPerFrame = 576
+	}
+	mono := h[3]>>6 == 3
+
+	// Xing/Info header, right after the side information
+	sideInfo := 32
+	switch {
+	case version == 3 && mono:
+		sideInfo = 17
+	case version != 3 && !mono:
+		sideInfo = 17
+	case version != 3 && mono:
+		sideInfo = 9
+	}
+	if x := 4 + sideInfo; len(h) >= x+12 {
+		tag := string(h[x : x+4])
+		if (tag == "Xing" || tag == "Info") && h[x+7]&0x01 != 0 {
+			frames := int64(binary.BigEndian.Uint32(h[x+8 : x+12]))
+			return int(frames * int64(samplesPerFrame) * 1000 / int64(sampleRate))
+		}
+	}
+
+	// VBRI header, 32 bytes after the frame header
+	if len(h) >= 36+18 && string(h[36:40]) == "VBRI" {
+		frames := int64(binary.BigEndian.Uint32(h[36+14 : 36+18]))
+		return int(frames * int64(samplesPerFrame) * 1000 / int64(sampleRate))
+	}
+
+	if bitrate == 0 {
+		return 0
+	}
+	return int((audioSize - int64(frame)) * 8 / int64(bitrate))
+}
+
+func findMPEGFrame(buf []byte) (int, error) {
+	for i := 0; i+4 <= len(buf); i++ {
+		if buf[i] == 0xff && buf[i+1]&0xe0 == 0xe0 && buf[i+2]>>4 != 0x0f && buf[i+2]>>4 != 0 {
+			return i, nil
+		}
+	}
+	return 0, errors.New("no mpeg frame found")
+}
@@ -0,0 +1,176 @@
+package library_test
+
+import (
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/FerNunez/NameThatSong/internal/library"
+)
+
+// id3v2 builds an ID3v2 tag of that version around the frames
+func id3v2(version byte, frames ...[]byte) []byte {
+	var body []byte
+	for _, frame := range frames {
+		body = append(body, frame...)
+	}
+	return append([]byte{'I', 'D', '3', version, 0, 0}, append(syncsafe(len(body)), body...)...)
+}
+
+func syncsafe(n int) []byte {
+	return []byte{byte(n >> 21 & 0x7f), byte(n >> 14 & 0x7f), byte(n >> 7 & 0x7f), byte(n & 0x7f)}
+}
+
+// id3Frame builds a frame, sized as the tag version writes it
+func id3Frame(version byte, id string, data []byte) []byte {
+	frame := []byte(id)
+	switch version {
+	case 2:
+		frame = append(frame, byte(len(data)>>16), byte(len(data)>>8), byte(len(data)))
+	case 3:
+		frame = binary.BigEndian.AppendUint32(frame, uint32(len(data)))
+		frame = append(frame, 0, 0)
+	default:
+		frame = append(frame, syncsafe(len(data))...)
+		frame = append(frame, 0, 0)
+	}
+	return append(frame, data...)
+}
+
+// latin1 is a text frame in ISO-8859-1
+func latin1(text string) []byte {
+	return append([]byte{0}, text...)
+}
+
+// utf16BOM is a text frame in little-endian UTF-16 with a byte order mark
+func utf16BOM(text string) []byte {
+	b := []byte{1, 0xff, 0xfe}
+	for _, u := range utf16.Encode([]rune(text)) {
+		b = binary.LittleEndian.AppendUint16(b, u)
+	}
+	return b
+}
+
+// mpegFrames is 16000 bytes of 128kbps MPEG 1 Layer III audio, one second
+func mpegFrames() []byte {
+	audio := make([]byte, 16000)
+	copy(audio, []byte{0xff, 0xfb, 0x90, 0x00})
+	return audio
+}
+
+// id3v1 builds the 128 byte ID3v1.1 tag ending a file
+func id3v1(title, artist, album, year string, track byte) []byte {
+	tag := make([]byte, 128)
+	copy(tag, "TAG")
+	copy(tag[3:33], title)
+	copy(tag[33:63], artist)
+	copy(tag[63:93], album)
+	copy(tag[93:97], year)
+	tag[126] = track
+	return tag
+}
+
+func TestReadMP3(t *testing.T) {
+	cover := []byte("\xff\xd8jpeg")
+	apic := append(append([]byte{0}, "image/png\x00"...), append([]byte{3}, append([]byte("cover\x00"), cover...)...)...)
+	pic := append(append([]byte{0}, "JPG"...), append([]byte{3}, append([]byte("\x00"), cover...)...)...)
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected library.Tags
+	}{
+		{
+			"id3v2.3",
+			append(id3v2(3,
+				id3Frame(3, "TIT2", latin1("Hey Jude")),
+				id3Frame(3, "TPE1", latin1("The Beatles")),
+				id3Frame(3, "TALB", latin1("Past Masters")),
+				id3Frame(3, "TYER", latin1("1968")),
+				id3Frame(3, "TRCK", latin1("3/12")),
+				id3Frame(3, "TPOS", latin1("2/2")),
+				id3Frame(3, "TLEN", latin1("431000")),
+			), mpegFrames()...),
+			library.Tags{Title: "Hey Jude", Artist: "The Beatles", Album: "Past Masters", Year: "1968", TrackNumber: 3, DiscNumber: 2, DurationMs: 431000},
+		},
+		{
+			"id3v2.4 utf-8 and cover",
+			append(id3v2(4,
+				id3Frame(4, "TIT2", append([]byte{3}, "Déjà Vu\x00Other"...)),
+				id3Frame(4, "TPE1", utf16BOM("Beyoncé")),
+				id3Frame(4, "TDRC", latin1("2006-09-01")),
+				id3Frame(4, "APIC", apic),
+			), mpegFrames()...),
+			library.Tags{Title: "Déjà Vu", Artist: "Beyoncé", Album: "Unknown Album", Year: "2006", DurationMs: 1000, Picture: cover, PictureMIME: "image/png"},
+		},
+		{
+			"id3v2.2",
+			append(id3v2(2,
+				id3Frame(2, "TT2", latin1("Heroes")),
+				id3Frame(2, "TP1", latin1("David Bowie")),
+				id3Frame(2, "TAL", latin1("Heroes")),
+				id3Frame(2, "PIC", pic),
+			), mpegFrames()...),
+			library.Tags{Title: "Heroes", Artist: "David Bowie", Album: "Heroes", DurationMs: 1000, Picture: cover, PictureMIME: "image/jpeg"},
+		},
+		{
+			"id3v1",
+			append(mpegFrames(), id3v1("Imagine", "John Lennon", "Imagine", "1971", 1)...),
+			library.Tags{Title: "Imagine", Artist: "John Lennon", Album: "Imagine", Year: "1971", TrackNumber: 1, DurationMs: 1008},
+		},
+		{
+			"no tags",
+			mpegFrames(),
+			library.Tags{Title: "song", Artist: "Unknown Artist", Album: "Unknown Album", DurationMs: 1000},
+		},
+		{
+			"frame larger than the tag",
+			append(id3v2(3,
+				id3Frame(3, "TIT2", latin1("Hey Jude")),
+				[]byte{'T', 'P', 'E', '1', 0x7f, 0xff, 0xff, 0xff, 0, 0, 0},
+			), mpegFrames()...),
+			library.Tags{Title: "Hey Jude", Artist: "Unknown Artist", Album: "Unknown Album", DurationMs: 1000},
+		},
+		{
+			"truncated picture",
+			append(id3v2(3, id3Frame(3, "APIC", []byte{0, 'i', 'm'})), mpegFrames()...),
+			library.Tags{Title: "song", Artist: "Unknown Artist", Album: "Unknown Album", DurationMs: 1000},
+		},
+		{
+			"empty text frame",
+			append(id3v2(4, id3Frame(4, "TIT2", []byte{})), mpegFrames()...),
+			library.Tags{Title: "song", Artist: "Unknown Artist", Album: "Unknown Album", DurationMs: 1000},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tags, err := readFixture(t, "song.mp3", tc.data)
+			if err != nil {
+				t.Fatalf("ReadTags() = %v", err)
+			}
+			checkTags(t, tags, tc.expected)
+		})
+	}
+}
+
+func TestReadMP3Malformed(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty file", nil},
+		{"truncated header", []byte("ID3\x03\x00")},
+		{"tag larger than the file", append([]byte("ID3\x03\x00\x00"), syncsafe(1<<20)...)},
+		{"truncated tag", id3v2(3, id3Frame(3, "TIT2", latin1("Hey Jude")))[:20]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readFixture(t, "song.mp3", tc.data)
+			if err == nil {
+				t.Errorf("ReadTags() succeeded, want an error")
+			}
+		})
+	}
+}
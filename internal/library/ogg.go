@@ -0,0 +1,104 @@
+package library
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// readOGG reads the Vorbis or Opus comment header of an OGG stream and the
+// granule position of its last page for the duration
+func readOGG(r io.ReadSeeker, size int64) (Tags, error) {
+	var tags Tags
+
+	packets, err := oggPackets(r, 2)
+	if err != nil {
+		return Tags{}, err
+	}
+	if len(packets) < 2 {
+		return Tags{}, errors.New("missing ogg headers")
+	}
+	// the page after the comments, the Vorbis setup header may follow
+	tags.AudioOffset, err = r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return Tags{}, err
+	}
+
+	id, comments := packets[0], packets[1]
+	var sampleRate int64
+	switch {
+	case len(id) >= 16 && string(id[:7]) == "\x01vorbis":
+		sampleRate = int64(binary.LittleEndian.Uint32(id[12:16]))
+		if !bytes.HasPrefix(comments, []byte("\x03vorbis")) {
+			return Tags{}, errors.New("missing vorbis comments")
+		}
+		readVorbisComments(&tags, comments[7:])
+	case len(id) >= 8 && string(id[:8]) == "OpusHead":
+		// Opus granule positions always count 48kHz samples
+		sampleRate = 48000
+		if !bytes.HasPrefix(comments, []byte("OpusTags")) {
+			return Tags{}, errors.New("missing opus tags")
+		}
+		readVorbisComments(&tags, comments[8:])
+	default:
+		return Tags{}, errors.New("unsupported ogg codec")
+	}
+
+	if granule := lastGranule(r, size); granule > 0 && sampleRate > 0 {
+		tags.DurationMs = int(granule * 1000 / sampleRate)
+	}
+	return tags, nil
+}
+
+// oggPackets reassembles the first n packets of the stream
+func oggPackets(r io.Reader, n int) ([][]byte, error) {
+	packets := make([][]byte, 0, n)
+	var current []byte
+	header := make([]byte, 27)
+
+	for len(packets) < n {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return packets, err
+		}
+		if string(header[:4]) != "OggS" {
+			return packets, errors.New("invalid ogg page")
+		}
+
+		segments := make([]byte, header[26])
+		if _, err := io.ReadFull(r, segments); err != nil {
+			return packets, err
+		}
+		for _, lacing := range segments {
+			segment := make([]byte, lacing)
+			if _, err := io.ReadFull(r, segment); err != nil {
+				return packets, err
+			}
+			current = append(current, segment...)
+			// a lacing value below 255 ends the packet
+			if lacing < 255 {
+				packets = append(packets, current)
+				current = nil
+			}
+		}
+	}
+	return packets, nil
+}
+
+// lastGranule finds the granule position of the last page of the stream
+func lastGranule(r io.ReadSeeker, size int64) int64 {
+	const tail = 64 * 1024
+	start := max(size-tail, 0)
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return 0
+	}
+	buf := make([]byte, size-start)
+	n, _ := io.ReadFull(r, buf)
+	buf = buf[:n]
+
+	i := bytes.LastIndex(buf, []byte("OggS"))
+	if i < 0 || i+14 > len(buf) {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint64(buf[i+6 : i+14]))
+}
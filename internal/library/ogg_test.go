@@ -0,0 +1,131 @@
+package library_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/library"
+)
+
+// oggPage builds a page carrying the packets, a packet of 255 bytes or more
+// takes several segments
+func oggPage(granule uint64, packets ...[]byte) []byte {
+	var segments, data []byte
+	for _, packet := range packets {
+		n := len(packet)
+		for ; n >= 255; n -= 255 {
+			segments = append(segments, 255)
+		}
+		segments = append(segments, byte(n))
+		data = append(data, packet...)
+	}
+
+	page := []byte("OggS\x00\x00")
+	page = binary.LittleEndian.AppendUint64(page, granule)
+	// serial number, sequence number and checksum
+	page = append(page, make([]byte, 12)...)
+	page = append(page, byte(len(segments)))
+	page = append(page, segments...)
+	return append(page, data...)
+}
+
+// vorbisID is a Vorbis identification header at that sample rate
+func vorbisID(sampleRate uint32) []byte {
+	id := []byte("\x01vorbis\x00\x00\x00\x00\x02")
+	id = binary.LittleEndian.AppendUint32(id, sampleRate)
+	return append(id, make([]byte, 14)...)
+}
+
+func oggFile(pages ...[]byte) []byte {
+	var data []byte
+	for _, page := range pages {
+		data = append(data, page...)
+	}
+	return data
+}
+
+func TestReadOGG(t *testing.T) {
+	cover := []byte("\xff\xd8jpeg")
+	picture := "METADATA_BLOCK_PICTURE=" + base64.StdEncoding.EncodeToString(flacPicture("image/jpeg", cover))
+
+	testCases := []struct {
+		name     string
+		file     string
+		data     []byte
+		expected library.Tags
+	}{
+		{
+			"vorbis",
+			"song.ogg",
+			oggFile(
+				oggPage(0, vorbisID(44100)),
+				oggPage(0, append([]byte("\x03vorbis"), vorbisComments("TITLE=Hey Jude", "ARTIST=The Beatles", "DATE=1968")...)),
+				oggPage(441000, make([]byte, 100)),
+			),
+			library.Tags{Title: "Hey Jude", Artist: "The Beatles", Album: "Unknown Album", Year: "1968", DurationMs: 10000},
+		},
+		{
+			"opus with cover",
+			"song.opus",
+			oggFile(
+				oggPage(0, []byte("OpusHead\x01\x02\x00\x00\x80\xbb\x00\x00\x00\x00\x00")),
+				oggPage(0, append([]byte("OpusTags"), vorbisComments("TITLE=Heroes", "ALBUM=Heroes", picture)...)),
+				oggPage(240000, make([]byte, 100)),
+			),
+			library.Tags{Title: "Heroes", Artist: "Unknown Artist", Album: "Heroes", DurationMs: 5000, Picture: cover, PictureMIME: "image/jpeg"},
+		},
+		{
+			"headers in one page",
+			"song.ogg",
+			oggFile(
+				oggPage(0, vorbisID(48000), append([]byte("\x03vorbis"), vorbisComments("TITLE=Imagine")...)),
+			),
+			library.Tags{Title: "Imagine", Artist: "Unknown Artist", Album: "Unknown Album"},
+		},
+		{
+			"bad picture",
+			"song.opus",
+			oggFile(
+				oggPage(0, []byte("OpusHead\x01\x02\x00\x00\x80\xbb\x00\x00\x00\x00\x00")),
+				oggPage(0, append([]byte("OpusTags"), vorbisComments("TITLE=Heroes", "METADATA_BLOCK_PICTURE=not base64!")...)),
+			),
+			library.Tags{Title: "Heroes", Artist: "Unknown Artist", Album: "Unknown Album"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tags, err := readFixture(t, tc.file, tc.data)
+			if err != nil {
+				t.Fatalf("ReadTags() = %v", err)
+			}
+			checkTags(t, tags, tc.expected)
+		})
+	}
+}
+
+func TestReadOGGMalformed(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty file", nil},
+		{"not ogg", []byte("fLaC\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{"truncated page header", []byte("OggS\x00\x00")},
+		{"truncated segment", oggPage(0, vorbisID(44100))[:40]},
+		{"missing comments", oggFile(oggPage(0, vorbisID(44100)))},
+		{"wrong comment header", oggFile(oggPage(0, vorbisID(44100)), oggPage(0, []byte("OpusTags")))},
+		{"unknown codec", oggFile(oggPage(0, []byte("\x7fFLAC")), oggPage(0, []byte("comments")))},
+		{"short vorbis header", oggFile(oggPage(0, []byte("\x01vorbis")), oggPage(0, []byte("\x03vorbis")))},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readFixture(t, "song.ogg", tc.data)
+			if err == nil {
+				t.Errorf("ReadTags() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+)
+
+var (
+	errNoAuth      = errors.New("local music library does not need authorization")
+	errNoPlaylists = errors.New("local music library has no playlists")
+	errNoAccount   = errors.New("local music library has no listening history")
+	errNoDevices   = errors.New("local music library plays in the browser")
+)
+
+// LocalSongProvider implements the SongProvider interface on top of a local
+// music library. Audio is streamed to the browser, so playback calls are no-ops
+type LocalSongProvider struct {
+	Library *Library
+}
+
+var (
+	_ spotify_api.SongProvider  = (*LocalSongProvider)(nil)
+	_ spotify_api.TrackStreamer = (*LocalSongProvider)(nil)
+)
+
+// NewLocalSongProvider creates a new LocalSongProvider
+func NewLocalSongProvider(library *Library) *LocalSongProvider {
+	return &LocalSongProvider{Library: library}
+}
+
+func (p *LocalSongProvider) RequiresAuth() bool {
+	return false
+}
+
+func (p *LocalSongProvider) AuthRequestURL() (string, error) {
+	return "", errNoAuth
+}
+
+func (p *LocalSongProvider) ValidateState(state string) error {
+	return errNoAuth
+}
+
+func (p *LocalSongProvider) TokenExchange(ctx context.Context, code string) (spotify_api.TokenResponse, error) {
+	return spotify_api.TokenResponse{}, errNoAuth
+}
+
+func (p *LocalSongProvider) RegenerateToken(ctx context.Context) (spotify_api.TokenResponse, error) {
+	return spotify_api.TokenResponse{}, errNoAuth
+}
+
+func (p *LocalSongProvider) SearchArtistsByName(ctx context.Context, accessToken, name string) ([]spotify_api.ArtistData, error) {
+	query := strings.ToLower(strings.TrimSpace(name))
+
+	artists := make([]spotify_api.ArtistData, 0)
+	for _, artist := range p.Library.Artists {
+		if strings.Contains(strings.ToLower(artist.Name), query) {
+			artists = append(artists, artist)
+		}
+	}
+
+	sort.Slice(artists, func(i, j int) bool {
+		return artists[i].Popularity > artists[j].Popularity
+	})
+	return artists, nil
+}
+
+// FetchAlbumByArtistID returns the albums of the artist. Tags carry no album
+// group, so every local album belongs to the "album" group
+func (p *LocalSongProvider) FetchAlbumByArtistID(ctx context.Context, accessToken, artistId string, groups []string) ([]spotify_api.AlbumData, error) {
+	albumIds, ok := p.Library.ArtistToAlbums[artistId]
+	if !ok {
+		return nil, errors.New("unknown artist in music library")
+	}
+	if !slices.Contains(groups, spotify_api.AlbumGroupAlbum) {
+		return []spotify_api.AlbumData{}, nil
+	}
+
+	albums := make([]spotify_api.AlbumData, 0, len(albumIds))
+	for _, albumId := range albumIds {
+		albums = append(albums, p.Library.Albums[albumId])
+	}
+	return albums, nil
+}
+
+func (p *LocalSongProvider) FetchTracksByAlbumID(ctx context.Context, accessToken, albumId string) ([]spotify_api.TrackData, error) {
+	trackIds, ok := p.Library.AlbumToTracks[albumId]
+	if !ok {
+		return nil, errors.New("unknown album in music library")
+	}
+
+	tracks := make([]spotify_api.TrackData, 0, len(trackIds))
+	for _, trackId := range trackIds {
+		tracks = append(tracks, p.Library.Tracks[trackId].TrackData)
+	}
+	return tracks, nil
+}
+
+// CreateAlbumFromTopTracks has no popularity to rank by, so the fake album
+// holds every track of the artist
+func (p *LocalSongProvider) CreateAlbumFromTopTracks(ctx context.Context, accessToken, artistId string) (spotify_api.AlbumData, []spotify_api.TrackData, error) {
+	albums, err := p.FetchAlbumByArtistID(ctx, accessToken, artistId, spotify_api.AlbumGroups)
+	if err != nil {
+		return spotify_api.AlbumData{}, nil, err
+	}
+
+	trackList := make([]spotify_api.TrackData, 0)
+	for _, album := range albums {
+		tracks, err := p.FetchTracksByAlbumID(ctx, accessToken, album.ID)
+		if err != nil {
+			return spotify_api.AlbumData{}, nil, err
+		}
+		trackList = append(trackList, tracks...)
+	}
+
+	album := spotify_api.AlbumData{
+		AlbumType:   "TopTracks",
+		TotalTracks: len(trackList),
+		ID:          "topTracks" + artistId,
+		ImagesURL:   "",
+		Name:        "All songs",
+		ReleaseDate: "ALL",
+	}
+	return album, trackList, nil
+}
+
+// FetchUserPlaylists returns no playlists, the library only knows albums
+func (p *LocalSongProvider) FetchUserPlaylists(ctx context.Context, accessToken string) ([]spotify_api.PlaylistData, error) {
+	return []spotify_api.PlaylistData{}, nil
+}
+
+func (p *LocalSongProvider) FetchPlaylist(ctx context.Context, accessToken, playlistId string) (spotify_api.PlaylistData, error) {
+	return spotify_api.PlaylistData{}, errNoPlaylists
+}
+
+func (p *LocalSongProvider) FetchPlaylistTracks(ctx context.Context, accessToken, playlistId string) ([]spotify_api.PlaylistTrack, error) {
+	return nil, errNoPlaylists
+}
+
+func (p *LocalSongProvider) FetchSavedTracks(ctx context.Context, accessToken string) ([]spotify_api.PlaylistTrack, error) {
+	return nil, errNoAccount
+}
+
+func (p *LocalSongProvider) FetchTopTracks(ctx context.Context, accessToken, timeRange string) ([]spotify_api.PlaylistTrack, error) {
+	return nil, errNoAccount
+}
+
+func (p *LocalSongProvider) FetchTopArtists(ctx context.Context, accessToken, timeRange string) ([]spotify_api.ArtistData, error) {
+	return nil, errNoAccount
+}
+
+func (p *LocalSongProvider) PlaySong(ctx context.Context, accessToken, deviceId, songID string, positionMs int) error {
+	if _, ok := p.Library.Tracks[songID]; !ok {
+		return errors.New("unknown track in music library")
+	}
+	return nil
+}
+
+func (p *LocalSongProvider) PausePlayback(ctx context.Context, accessToken, deviceId string) error {
+	return nil
+}
+
+func (p *LocalSongProvider) ResumePlayback(ctx context.Context, accessToken, deviceId string) error {
+	return nil
+}
+
+// FetchDevices returns no devices, the browser is the only player
+func (p *LocalSongProvider) FetchDevices(ctx context.Context, accessToken string) ([]spotify_api.DeviceData, error) {
+	return []spotify_api.DeviceData{}, nil
+}
+
+func (p *LocalSongProvider) TransferPlayback(ctx context.Context, accessToken, deviceId string, play bool) error {
+	return errNoDevices
+}
+
+func (p *LocalSongProvider) TrackPath(trackId string) (string, int64, error) {
+	track, ok := p.Library.Tracks[trackId]
+	if !ok {
+		return "", 0, errors.New("unknown track in music library")
+	}
+	return track.Path, track.AudioOffset, nil
+}
+
+func (p *LocalSongProvider) AlbumCover(albumId string) ([]byte, string, error) {
+	data, mime, ok := p.Library.Cover(albumId)
+	if !ok {
+		return nil, "", errors.New("album has no cover")
+	}
+	return data, mime, nil
+}
@@ -0,0 +1,122 @@
+package library
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Tags holds the metadata read from an audio file
+type Tags struct {
+	Title       string
+	Artist      string
+	Album       string
+	Year        string
+	TrackNumber int
+	DiscNumber  int
+	DurationMs  int
+	Picture     []byte
+	PictureMIME string
+	// AudioOffset is the byte the audio starts at, after the tags
+	AudioOffset int64
+}
+
+// supportedExtensions lists the audio files picked up by the scanner
+var supportedExtensions = map[string]bool{
+	".mp3":  true,
+	".flac": true,
+	".ogg":  true,
+	".oga":  true,
+	".opus": true,
+}
+
+// ReadTags reads the tags of an MP3, FLAC or OGG file
+func ReadTags(path string) (Tags, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Tags{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return Tags{}, err
+	}
+
+	var tags Tags
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mp3":
+		tags, err = readMP3(f, info.Size())
+	case ".flac":
+		tags, err = readFLAC(f)
+	case ".ogg", ".oga", ".opus":
+		tags, err = readOGG(f, info.Size())
+	default:
+		return Tags{}, fmt.Errorf("unsupported audio file: %v", path)
+	}
+	if err != nil {
+		return Tags{}, fmt.Errorf("reading tags of %v: %w", path, err)
+	}
+
+	if tags.Title == "" {
+		tags.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if tags.Artist == "" {
+		tags.Artist = "Unknown Artist"
+	}
+	if tags.Album == "" {
+		tags.Album = "Unknown Album"
+	}
+	return tags, nil
+}
+
+// setVorbisComment maps a Vorbis comment (also used by FLAC) onto tags
+func (t *Tags) setVorbisComment(comment string) {
+	key, value, ok := strings.Cut(comment, "=")
+	if !ok {
+		return
+	}
+
+	switch strings.ToUpper(key) {
+	case "TITLE":
+		t.Title = value
+	case "ARTIST":
+		if t.Artist == "" {
+			t.Artist = value
+		}
+	case "ALBUM":
+		t.Album = value
+	case "DATE", "YEAR":
+		t.Year = parseYear(value)
+	case "TRACKNUMBER":
+		t.TrackNumber = parseNumber(value)
+	case "DISCNUMBER":
+		t.DiscNumber = parseNumber(value)
+	case "METADATA_BLOCK_PICTURE":
+		if t.Picture == nil {
+			t.setBase64Picture(value)
+		}
+	}
+}
+
+// parseYear keeps the year of dates such as "1975-10-31"
+func parseYear(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > 4 {
+		return s[:4]
+	}
+	return s
+}
+
+// parseNumber reads numbers such as "3" or "3/12"
+func parseNumber(s string) int {
+	n := 0
+	for _, r := range strings.TrimSpace(s) {
+		if r < '0' || r > '9' {
+			break
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n
+}
@@ -0,0 +1,101 @@
+package library_test
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/library"
+)
+
+// readFixture writes the bytes to a file with that name and reads its tags
+func readFixture(t *testing.T, name string, data []byte) (library.Tags, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return library.ReadTags(path)
+}
+
+// vorbisComments builds a little-endian Vorbis comment structure
+func vorbisComments(comments ...string) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, 6)
+	b = append(b, "tester"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(comments)))
+	for _, comment := range comments {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(comment)))
+		b = append(b, comment...)
+	}
+	return b
+}
+
+// flacPicture builds a big-endian FLAC PICTURE block
+func flacPicture(mime string, data []byte) []byte {
+	b := binary.BigEndian.AppendUint32(nil, 3)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(mime)))
+	b = append(b, mime...)
+	b = binary.BigEndian.AppendUint32(b, 0)
+	b = append(b, make([]byte, 16)...)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+func checkTags(t *testing.T, got, want library.Tags) {
+	t.Helper()
+	if got.Title != want.Title || got.Artist != want.Artist || got.Album != want.Album || got.Year != want.Year {
+		t.Errorf("tags = %q, %q, %q, %q, want %q, %q, %q, %q",
+			got.Title, got.Artist, got.Album, got.Year, want.Title, want.Artist, want.Album, want.Year)
+	}
+	if got.TrackNumber != want.TrackNumber || got.DiscNumber != want.DiscNumber || got.DurationMs != want.DurationMs {
+		t.Errorf("track %d, disc %d, duration %dms, want %d, %d, %dms",
+			got.TrackNumber, got.DiscNumber, got.DurationMs, want.TrackNumber, want.DiscNumber, want.DurationMs)
+	}
+	if string(got.Picture) != string(want.Picture) || got.PictureMIME != want.PictureMIME {
+		t.Errorf("picture %q (%v), want %q (%v)", got.Picture, got.PictureMIME, want.Picture, want.PictureMIME)
+	}
+}
+
+func TestReadTagsUnsupported(t *testing.T) {
+	_, err := readFixture(t, "song.wav", []byte("RIFF"))
+	if err == nil {
+		t.Errorf("ReadTags() of a wav file succeeded, want an error")
+	}
+}
+
+func TestAudioOffset(t *testing.T) {
+	tag := id3v2(3, id3Frame(3, "TIT2", latin1("Hey Jude")))
+	flac := flacFile(
+		flacBlock(0, false, streamInfo(44100, 441000)),
+		flacBlock(4, true, vorbisComments("TITLE=Hey Jude")),
+	)
+	headers := oggFile(
+		oggPage(0, vorbisID(44100)),
+		oggPage(0, append([]byte("\x03vorbis"), vorbisComments("TITLE=Hey Jude")...)),
+	)
+
+	testCases := []struct {
+		name     string
+		file     string
+		data     []byte
+		expected int64
+	}{
+		{"mp3 after the id3 tag", "song.mp3", append(tag, mpegFrames()...), int64(len(tag))},
+		{"mp3 without tag", "song.mp3", mpegFrames(), 0},
+		{"flac after the metadata", "song.flac", append(flac, make([]byte, 100)...), int64(len(flac))},
+		{"ogg after the comments", "song.ogg", append(headers, oggPage(441000, make([]byte, 100))...), int64(len(headers))},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tags, err := readFixture(t, tc.file, tc.data)
+			if err != nil {
+				t.Fatalf("ReadTags() = %v", err)
+			}
+			if tags.AudioOffset != tc.expected {
+				t.Errorf("AudioOffset = %d, want %d", tags.AudioOffset, tc.expected)
+			}
+		})
+	}
+}
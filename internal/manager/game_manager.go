@@ -4,52 +4,110 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 
+	"github.com/FerNunez/NameThatSong/internal/library"
 	"github.com/FerNunez/NameThatSong/internal/middleware"
 	"github.com/FerNunez/NameThatSong/internal/service"
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
 	"github.com/FerNunez/NameThatSong/internal/store"
+	"github.com/FerNunez/NameThatSong/internal/utils"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 )
 
+// ProviderFactory creates the song provider backing a new game
+type ProviderFactory func() (spotify_api.SongProvider, error)
+
 type GameManager struct {
 	Games             map[string]*service.GameService
 	SpotifyTokenStore store.SpotifyTokenStore
+	NewSongProvider   ProviderFactory
+	// SettingsStore and ScoreStore keep the settings and survival scores
+	// of the users' games, HistoryStore the games played, if set
+	SettingsStore store.SettingsStore
+	ScoreStore    store.ScoreStore
+	HistoryStore  store.HistoryStore
 }
 
-func NewGameManager() *GameManager {
+func NewGameManager(newSongProvider ProviderFactory) *GameManager {
 	return &GameManager{
-		Games: make(map[string]*service.GameService),
+		Games:           make(map[string]*service.GameService),
+		NewSongProvider: newSongProvider,
 	}
 }
 
-func (gm *GameManager) CreateGame(userId uuid.UUID, spotifyTokenStore store.SpotifyTokenStore) error {
+// NewSpotifyProviderFactory returns a factory creating one Spotify provider,
+// with its own OAuth state, per game
+func NewSpotifyProviderFactory() ProviderFactory {
+	return func() (spotify_api.SongProvider, error) {
+		// Load environment variables
+		err := godotenv.Load()
+		if err != nil {
+			return nil, fmt.Errorf("error loading .env file: %v", err)
+		}
 
-	// Load environment variables
-	err := godotenv.Load()
-	if err != nil {
-		return fmt.Errorf("error loading .env file: %v", err)
-	}
+		clientID := os.Getenv("CLIENT_ID")
+		clientSecret := os.Getenv("CLIENT_SECRET")
+		if clientID == "" || clientSecret == "" {
+			return nil, fmt.Errorf("missing Spotify credentials in .env file")
+		}
+
+		//redirectURI := "http://127.0.0.1:8080/auth/callback"
+		redirectURI := os.Getenv("SPOTIFY_REDIRECT_URI")
+		if redirectURI == "" {
+			redirectURI = "http://127.0.0.1:8080/auth/callback"
+			//"https://namethatsong.onrender.com/auth/callback"
+		}
+
+		// Generate a random state for OAuth
+		state, err := utils.GenerateState(16)
+		if err != nil {
+			return nil, fmt.Errorf("error generating state: %v", err)
+		}
+
+		songProvider := spotify_api.NewSpotifySongProvider(clientID, clientSecret, redirectURI, state)
 
-	clientID := os.Getenv("CLIENT_ID")
-	clientSecret := os.Getenv("CLIENT_SECRET")
-	if clientID == "" || clientSecret == "" {
-		return fmt.Errorf("missing Spotify credentials in .env file")
+		// Point to a stand-in Spotify server for staging and tests
+		if apiURL := os.Getenv("SPOTIFY_API_URL"); apiURL != "" {
+			songProvider.APIBaseURL = apiURL
+		}
+		if accountsURL := os.Getenv("SPOTIFY_ACCOUNTS_URL"); accountsURL != "" {
+			songProvider.AccountsBaseURL = accountsURL
+		}
+		if maxItems, err := strconv.Atoi(os.Getenv("SPOTIFY_MAX_PAGED_ITEMS")); err == nil {
+			songProvider.MaxPagedItems = maxItems
+		}
+		return songProvider, nil
 	}
+}
 
-	//redirectURI := "http://127.0.0.1:8080/auth/callback"
-	redirectURI := os.Getenv("SPOTIFY_REDIRECT_URI")
-	if redirectURI == "" {
-		redirectURI = "http://127.0.0.1:8080/auth/callback"
-		//"https://namethatsong.onrender.com/auth/callback"
+// NewLibraryProviderFactory returns a factory sharing one scanned music
+// library between all games
+func NewLibraryProviderFactory(lib *library.Library) ProviderFactory {
+	return func() (spotify_api.SongProvider, error) {
+		return library.NewLocalSongProvider(lib), nil
 	}
+}
+
+// CreateGame creates the user's game with the settings they last played with
+func (gm *GameManager) CreateGame(ctx context.Context, userId uuid.UUID, spotifyTokenStore store.SpotifyTokenStore) error {
 
-	gameService, err := service.NewGameService(clientID, clientSecret, redirectURI, userId, spotifyTokenStore)
+	songProvider, err := gm.NewSongProvider()
 	if err != nil {
 		return err
 	}
 
-	gm.Games[userId.String()] = gameService
+	game := service.NewGameService(songProvider, userId, spotifyTokenStore)
+	game.SettingsStore = gm.SettingsStore
+	game.ScoreStore = gm.ScoreStore
+	game.HistoryStore = gm.HistoryStore
+	err = game.LoadSettings(ctx)
+	if err != nil {
+		fmt.Printf("error loading game settings: %v\n", err)
+	}
+
+	gm.Games[userId.String()] = game
 	return nil
 }
 
@@ -26,6 +26,9 @@ type MusicPlayer struct {
 	CurrentIndex int
 	Timer        time.Time
 	SongDuration time.Duration
+	// ClipOffset is where in the track playback started, SongDuration is
+	// then the length of the clip
+	ClipOffset time.Duration
 }
 
 func NewMusicPlayer() *MusicPlayer {
@@ -46,6 +49,7 @@ func (p *MusicPlayer) GetQueue() []Song {
 func (p *MusicPlayer) ClearQueue() {
 	p.Queue = []Song{}
 	p.CurrentIndex = 0
+	p.ClipOffset = 0
 }
 
 // Shuffle shuffles the queue
@@ -62,15 +66,28 @@ func (p *MusicPlayer) Shuffle() {
 	p.CurrentIndex = 0
 }
 
+// ErrQueueEnd is returned when there is no song after the current one
+var ErrQueueEnd = errors.New("cannot next song as last song in the queue")
+
+// NextInQueue moves on to the next song, the current one stays when it is
+// the last
 func (p *MusicPlayer) NextInQueue() (Song, error) {
-	if p.CurrentIndex >= len(p.Queue) {
-		return Song{}, errors.New("cannot next song as last song in the queue")
+	if p.CurrentIndex+1 >= len(p.Queue) {
+		return Song{}, ErrQueueEnd
 	}
 
 	p.CurrentIndex += 1
 	return p.Queue[p.CurrentIndex], nil
+}
 
+// CurrentSong returns the song being played, if any
+func (p *MusicPlayer) CurrentSong() (Song, bool) {
+	if p.CurrentIndex < 0 || p.CurrentIndex >= len(p.Queue) {
+		return Song{}, false
+	}
+	return p.Queue[p.CurrentIndex], true
 }
+
 func (p *MusicPlayer) SongOver() bool {
 	return time.Since(p.Timer) >= p.SongDuration
 }
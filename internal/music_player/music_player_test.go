@@ -0,0 +1,26 @@
+package player_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/music_player"
+)
+
+func TestNextInQueue(t *testing.T) {
+	p := player.NewMusicPlayer()
+	p.AddToQueue([]player.Song{{TrackId: "a"}, {TrackId: "b"}})
+
+	song, err := p.NextInQueue()
+	if err != nil || song.TrackId != "b" {
+		t.Fatalf("NextInQueue() = %v, %v, want b", song, err)
+	}
+	// the last song stays current
+	_, err = p.NextInQueue()
+	if !errors.Is(err, player.ErrQueueEnd) {
+		t.Errorf("NextInQueue() after the last song = %v, want ErrQueueEnd", err)
+	}
+	if current, ok := p.CurrentSong(); !ok || current.TrackId != "b" {
+		t.Errorf("CurrentSong() = %v, %v, want b", current, ok)
+	}
+}
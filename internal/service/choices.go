@@ -0,0 +1,76 @@
+package service
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/FerNunez/NameThatSong/internal/music_player"
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+)
+
+// ChoiceCount is how many titles each song offers in multiple-choice mode
+const ChoiceCount = 4
+
+// choicesFor picks the titles offered for the song: its own and distractors
+// from the same album, then the same artist, then the rest of the game and
+// then any track seen. Titles that read the same once normalised, as
+// "Hurt" and "Hurt - Live", are offered once so only the answer is right
+func (s *GameService) choicesFor(song player.Song, track spotify_api.TrackData) []string {
+	key := func(title string) string {
+		return strings.Join(s.GuessState.Pipeline.Words(title), " ")
+	}
+	seen := map[string]bool{key(track.Name): true}
+	choices := []string{track.Name}
+
+	add := func(trackIds []string) {
+		trackIds = append([]string(nil), trackIds...)
+		rand.Shuffle(len(trackIds), func(i, j int) {
+			trackIds[i], trackIds[j] = trackIds[j], trackIds[i]
+		})
+		for _, trackId := range trackIds {
+			if len(choices) == ChoiceCount {
+				return
+			}
+			distractor, ok := s.Cache.TrackMap[trackId]
+			if !ok {
+				continue
+			}
+			k := key(distractor.Name)
+			if k == "" || seen[k] {
+				continue
+			}
+			seen[k] = true
+			choices = append(choices, distractor.Name)
+		}
+	}
+
+	add(s.Cache.AlbumToTracksMap[song.AlbumId])
+
+	var artistTracks []string
+	for _, albumId := range s.Cache.ArtistToAlbumsMap[song.ArtistId] {
+		artistTracks = append(artistTracks, s.Cache.AlbumToTracksMap[albumId]...)
+	}
+	for trackId, artistId := range s.Cache.TrackIdToArtistId {
+		if artistId == song.ArtistId {
+			artistTracks = append(artistTracks, trackId)
+		}
+	}
+	add(artistTracks)
+
+	var gameTracks []string
+	for trackId := range s.TracksToPlayId {
+		gameTracks = append(gameTracks, trackId)
+	}
+	add(gameTracks)
+
+	var cachedTracks []string
+	for trackId := range s.Cache.TrackMap {
+		cachedTracks = append(cachedTracks, trackId)
+	}
+	add(cachedTracks)
+
+	rand.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+	return choices
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/FerNunez/NameThatSong/internal/cache"
@@ -11,50 +13,71 @@ import (
 	"github.com/FerNunez/NameThatSong/internal/music_player"
 	"github.com/FerNunez/NameThatSong/internal/spotify_api"
 	"github.com/FerNunez/NameThatSong/internal/store"
-	"github.com/FerNunez/NameThatSong/internal/utils"
 	"github.com/google/uuid"
 )
 
 // GameService coordinates the song provider and music player
 type GameService struct {
 	MusicPlayer       *player.MusicPlayer
-	SpotifyApi        *spotify_api.SpotifySongProvider
+	SongProvider      spotify_api.SongProvider
 	AlbumSelection    map[string]bool
 	ArtistSelection   map[string]uint8
+	PlaylistSelection map[string]bool
+	SourceSelection   map[string]bool
 	TracksToPlayId    map[string]*player.Song
 	GuessState        *game.GuessState
 	Cache             *cache.SpotifyCache
 	UserId            uuid.UUID
 	SpotifyToken      store.SpotifyToken
 	SpotifyTokenStore store.SpotifyTokenStore
-}
 
-// NewGameService creates a new game service
-func NewGameService(clientID, clientSecret, redirectURI string, userId uuid.UUID, spotifyTokenStore store.SpotifyTokenStore) (*GameService, error) {
+	// DeviceId is the Spotify Connect device the game plays on, empty
+	// targets whatever device is active
+	DeviceId string
+	// BrowserDeviceId is the Web Playback SDK device of the game page
+	BrowserDeviceId string
+
+	// Settings the next game starts with
+	Settings      GameSettings
+	SettingsStore store.SettingsStore
+	clipStop      *time.Timer
+
+	// Rounds are the songs of the game played so far, the game is over
+	// once the last one is left
+	Rounds   []RoundResult
+	GameOver bool
+	// ScoreStore keeps the scores of survival games, BestSurvived is the
+	// best one once the game is over
+	ScoreStore   store.ScoreStore
+	BestSurvived int
+	// HistoryStore records the games, their rounds and guesses, the ids
+	// are the recorded game and round being played
+	HistoryStore   store.HistoryStore
+	historyGameId  uuid.UUID
+	historyRoundId uuid.UUID
+}
 
-	// Generate a random state for OAuth
-	state, err := utils.GenerateState(16)
-	if err != nil {
-		return nil, fmt.Errorf("error generating state: %v", err)
-	}
+// NewGameService creates a new game service on top of the given song provider
+func NewGameService(songProvider spotify_api.SongProvider, userId uuid.UUID, spotifyTokenStore store.SpotifyTokenStore) *GameService {
 
-	// Create song provider
-	songProvider := spotify_api.NewSpotifySongProvider(clientID, clientSecret, redirectURI, state)
 	// Create music service client
 	musicPlayer := player.NewMusicPlayer()
 	// Create game service
 	guessState := game.NewGameState()
 	return &GameService{
 		MusicPlayer:       musicPlayer,
-		SpotifyApi:        songProvider,
+		SongProvider:      songProvider,
 		AlbumSelection:    make(map[string]bool),
 		ArtistSelection:   make(map[string]uint8),
+		PlaylistSelection: make(map[string]bool),
+		SourceSelection:   make(map[string]bool),
 		TracksToPlayId:    make(map[string]*player.Song),
 		Cache:             cache.NewSpotifyCache(),
 		GuessState:        guessState,
 		UserId:            userId,
 		SpotifyTokenStore: spotifyTokenStore,
-	}, nil
+		Settings:          DefaultSettings(),
+	}
 }
 
 // SelectAlbum selects or deselects an album
@@ -90,154 +113,490 @@ func (s *GameService) GetSelectedAlbums() []string {
 	return albums
 }
 
+// TogglePlaylistSelection selects or deselects a playlist
+func (s *GameService) TogglePlaylistSelection(playlistId string) bool {
+	if s.PlaylistSelection[playlistId] {
+		delete(s.PlaylistSelection, playlistId)
+		return false
+	}
+	s.PlaylistSelection[playlistId] = true
+	return true
+}
+
+// ToggleSourceSelection selects or deselects a personal source
+func (s *GameService) ToggleSourceSelection(sourceId string) (bool, error) {
+	if _, ok := FindPersonalSource(sourceId); !ok {
+		return false, fmt.Errorf("unknown song source: %v", sourceId)
+	}
+	if s.SourceSelection[sourceId] {
+		delete(s.SourceSelection, sourceId)
+		return false, nil
+	}
+	s.SourceSelection[sourceId] = true
+	return true, nil
+}
+
 func (s GameService) SearchArtists(ctx context.Context, artist string) ([]spotify_api.ArtistData, error) {
 
 	err := s.EnsureAccessToken(ctx)
 	if err != nil {
-		return []spotify_api.ArtistData{}, fmt.Errorf("No token spotify available")
+		return []spotify_api.ArtistData{}, fmt.Errorf("No token spotify available: %w", err)
 	}
 
-	artists, err := s.SpotifyApi.SearchArtistsByName(s.SpotifyToken.AccessToken, artist)
+	artists, err := s.SongProvider.SearchArtistsByName(ctx, s.SpotifyToken.AccessToken, artist)
 	for _, artist := range artists {
 		s.Cache.ArtistMap[artist.Id] = artist
 	}
 	return artists, err
 }
 
-func (s GameService) GetArtistsAlbum(ctx context.Context, artistId string) ([]spotify_api.AlbumData, error) {
+// GetArtistsAlbum returns the albums of the artist in the given album groups
+func (s GameService) GetArtistsAlbum(ctx context.Context, artistId string, groups []string) ([]spotify_api.AlbumData, error) {
 
 	err := s.EnsureAccessToken(ctx)
 	if err != nil {
-		return []spotify_api.AlbumData{}, fmt.Errorf("No token spotify available")
+		return []spotify_api.AlbumData{}, fmt.Errorf("No token spotify available: %w", err)
 	}
 
-	return s.Cache.GetArtistsAlbum(s.SpotifyApi, s.SpotifyToken.AccessToken, artistId)
+	return s.Cache.GetArtistsAlbum(ctx, s.SongProvider, s.SpotifyToken.AccessToken, artistId, groups)
 }
 
 func (s GameService) GetAlbumTracks(ctx context.Context, albumId string) ([]spotify_api.TrackData, error) {
 	err := s.EnsureAccessToken(ctx)
 	if err != nil {
-		return []spotify_api.TrackData{}, fmt.Errorf("No token spotify available")
+		return []spotify_api.TrackData{}, fmt.Errorf("No token spotify available: %w", err)
 	}
-	return s.Cache.GetAlbumTracks(s.SpotifyApi, s.SpotifyToken.AccessToken, albumId)
+	return s.Cache.GetAlbumTracks(ctx, s.SongProvider, s.SpotifyToken.AccessToken, albumId)
 }
 
-// StartGame prepares the game with selected albums
+// GetUserPlaylists returns the playlists of the connected account
+func (s GameService) GetUserPlaylists(ctx context.Context) ([]spotify_api.PlaylistData, error) {
+	err := s.EnsureAccessToken(ctx)
+	if err != nil {
+		return []spotify_api.PlaylistData{}, fmt.Errorf("No token spotify available: %w", err)
+	}
+	return s.Cache.GetUserPlaylists(ctx, s.SongProvider, s.SpotifyToken.AccessToken)
+}
+
+// FindPlaylist looks up a playlist from a pasted URL, URI or ID
+func (s GameService) FindPlaylist(ctx context.Context, ref string) (spotify_api.PlaylistData, error) {
+	playlistId, err := spotify_api.ParsePlaylistID(ref)
+	if err != nil {
+		return spotify_api.PlaylistData{}, err
+	}
+
+	err = s.EnsureAccessToken(ctx)
+	if err != nil {
+		return spotify_api.PlaylistData{}, fmt.Errorf("No token spotify available: %w", err)
+	}
+	return s.Cache.GetPlaylist(ctx, s.SongProvider, s.SpotifyToken.AccessToken, playlistId)
+}
+
+func (s GameService) GetPlaylistTracks(ctx context.Context, playlistId string) ([]spotify_api.PlaylistTrack, error) {
+	err := s.EnsureAccessToken(ctx)
+	if err != nil {
+		return []spotify_api.PlaylistTrack{}, fmt.Errorf("No token spotify available: %w", err)
+	}
+	return s.Cache.GetPlaylistTracks(ctx, s.SongProvider, s.SpotifyToken.AccessToken, playlistId)
+}
+
+// GetSourceTracks returns the tracks of a personal source
+func (s GameService) GetSourceTracks(ctx context.Context, source PersonalSource) ([]spotify_api.PlaylistTrack, error) {
+	err := s.EnsureAccessToken(ctx)
+	if err != nil {
+		return []spotify_api.PlaylistTrack{}, fmt.Errorf("No token spotify available: %w", err)
+	}
+
+	switch source.Kind {
+	case SourceLikedSongs:
+		return s.Cache.GetSavedTracks(ctx, s.SongProvider, s.SpotifyToken.AccessToken)
+	case SourceTopTracks:
+		return s.Cache.GetTopTracks(ctx, s.SongProvider, s.SpotifyToken.AccessToken, source.TimeRange)
+	case SourceTopArtists:
+		return s.Cache.GetTopArtistsTracks(ctx, s.SongProvider, s.SpotifyToken.AccessToken, source.TimeRange)
+	default:
+		return []spotify_api.PlaylistTrack{}, fmt.Errorf("unknown song source: %v", source.ID)
+	}
+}
+
+// StartGame prepares the game with selected albums, playlists and personal
+// sources
 func (s *GameService) StartGame(ctx context.Context) error {
-	if len(s.AlbumSelection) <= 0 {
-		return errors.New("Empty album selection")
+	if len(s.AlbumSelection) <= 0 && len(s.PlaylistSelection) <= 0 && len(s.SourceSelection) <= 0 {
+		return errors.New("Empty album, playlist and source selection")
+	}
+	err := s.Settings.Validate()
+	if err != nil {
+		return err
 	}
 
 	for artistId := range s.ArtistSelection {
 		albumsId, ok := s.Cache.ArtistToAlbumsMap[artistId]
 		if !ok {
-			panic(fmt.Sprintf("artistId to albumId map should have artist: %v", artistId))
+			return fmt.Errorf("albums of artist %v are not cached", artistId)
 		}
 
 		for _, albumId := range albumsId {
+			// only fetch selected albums to keep the number of calls down
+			if _, exists := s.AlbumSelection[albumId]; !exists {
+				continue
+			}
+
 			tracksData, err := s.GetAlbumTracks(ctx, albumId)
 			if err != nil {
 				return err
 			}
 
-			_, exists := s.AlbumSelection[albumId]
-			if exists {
-				for _, track := range tracksData {
-					s.TracksToPlayId[track.ID] = player.NewSong(track.ID, albumId, artistId)
-				}
+			for _, track := range tracksData {
+				s.TracksToPlayId[track.ID] = player.NewSong(track.ID, albumId, artistId)
 			}
 		}
 
 	}
 
-	for _, song := range s.TracksToPlayId {
-		s.MusicPlayer.Queue = append(s.MusicPlayer.Queue, *song)
-	}
-	s.MusicPlayer.Shuffle()
-	song := s.MusicPlayer.Queue[s.MusicPlayer.CurrentIndex]
+	// playlist songs keep the album and artist they really belong to
+	for playlistId := range s.PlaylistSelection {
+		playlistTracks, err := s.GetPlaylistTracks(ctx, playlistId)
+		if err != nil {
+			return err
+		}
 
-	track, ok := s.Cache.TrackMap[song.TrackId]
-	if !ok {
-		panic("Track should always exist in cache")
-	}
-	album, ok := s.Cache.AlbumMap[song.AlbumId]
-	if !ok {
-		panic("Track should always exist in cache")
+		for _, playlistTrack := range playlistTracks {
+			trackId := playlistTrack.Track.ID
+			s.TracksToPlayId[trackId] = player.NewSong(trackId, playlistTrack.Album.ID, playlistTrack.Artist.Id)
+		}
 	}
-	artist, ok := s.Cache.ArtistMap[song.ArtistId]
-	if !ok {
-		panic("Track should always exist in cache")
+
+	for sourceId := range s.SourceSelection {
+		source, ok := FindPersonalSource(sourceId)
+		if !ok {
+			continue
+		}
+		sourceTracks, err := s.GetSourceTracks(ctx, source)
+		if err != nil {
+			return err
+		}
+
+		for _, sourceTrack := range sourceTracks {
+			trackId := sourceTrack.Track.ID
+			s.TracksToPlayId[trackId] = player.NewSong(trackId, sourceTrack.Album.ID, sourceTrack.Artist.Id)
+		}
 	}
 
-	// guessSong process:
-	s.GuessState.SetTitle(track.Name, artist.Name, album.ImagesURL)
-	s.MusicPlayer.Timer = time.Now()
-	s.MusicPlayer.SongDuration = time.Duration(track.DurationMs) * time.Millisecond
+	if len(s.TracksToPlayId) == 0 {
+		return errors.New("Selection has no playable tracks")
+	}
 
-	err := s.EnsureAccessToken(ctx)
+	err = s.playRounds(ctx)
 	if err != nil {
-		return fmt.Errorf("Couldnt not ensure refresh token")
+		return err
 	}
-	s.SpotifyApi.PlaySong(s.SpotifyToken.AccessToken, song.TrackId)
 
-	// Debug
-	println("track Name:", track.Name)
+	err = s.saveSettings(ctx)
+	if err != nil {
+		fmt.Printf("error saving game settings: %v\n", err)
+	}
 
 	return nil
 }
 
-// User tries to guess
-func (s *GameService) UserGuess(guess string) (bool, error) {
+// User tries to guess, in Heardle mode a wrong guess plays a longer part of
+// the song. Guesses after the survival deadline don't count
+func (s *GameService) UserGuess(ctx context.Context, guess string) (bool, error) {
+	if s.GameOver {
+		return false, nil
+	}
+	if s.GuessState.Timeout() {
+		return false, s.endIfOutOfLives(ctx)
+	}
 
+	unlocks := s.GuessState.Unlocks()
+	artistShown := s.GuessState.ArtistShown()
+	answerShown := s.GuessState.AnswerShown()
 	_, guessedCorrectly := s.GuessState.Guess(guess)
+	if !answerShown {
+		s.recordGuess(ctx, guess, guessedCorrectly)
+	}
 
+	// in survival mode naming another artist costs a life
+	if !artistShown && !s.GuessState.ArtistShown() && s.namesOtherArtist(guess) && s.GuessState.LoseLife() {
+		return guessedCorrectly, s.endIfOutOfLives(ctx)
+	}
+
+	if s.GuessState.Unlocks() != unlocks {
+		return guessedCorrectly, s.ReplaySong(ctx)
+	}
 	return guessedCorrectly, nil
 }
 
-// SkipSong skips to the next song
+// UserChoose picks one of the titles offered in multiple-choice mode, a
+// wrong one counts as a wrong guess
+func (s *GameService) UserChoose(ctx context.Context, choice int) (bool, error) {
+	if s.GameOver {
+		return false, nil
+	}
+	if s.GuessState.Timeout() {
+		return false, s.endIfOutOfLives(ctx)
+	}
+
+	unlocks := s.GuessState.Unlocks()
+	// only the choices that count are recorded
+	counts := !s.GuessState.AnswerShown() && choice >= 0 && choice < len(s.GuessState.Choices()) && !s.GuessState.RuledOut(choice)
+	chosenCorrectly := s.GuessState.Choose(choice)
+	if counts {
+		s.recordGuess(ctx, s.GuessState.Choices()[choice], chosenCorrectly)
+	}
+
+	if s.GuessState.Unlocks() != unlocks {
+		return chosenCorrectly, s.ReplaySong(ctx)
+	}
+	return chosenCorrectly, nil
+}
+
+// UseHint shows more of the current song for the points the hint costs
+func (s *GameService) UseHint(hint string) error {
+	if _, ok := s.GuessState.HintCosts[hint]; !ok {
+		return fmt.Errorf("unknown hint %v", hint)
+	}
+	s.GuessState.Hint(hint)
+	return nil
+}
+
+// RevealSong gives up on the current song and shows its answer
+func (s *GameService) RevealSong() {
+	s.GuessState.Reveal()
+}
+
+// SkipSong skips to the next song, which costs points unless the title was
+// named, and the game is over after the last one or the last life. In
+// Heardle mode a skip first unlocks the rest of the song
 func (s *GameService) SkipSong(ctx context.Context) error {
+	if s.GameOver {
+		return nil
+	}
+	if !s.GuessState.AnswerShown() && s.GuessState.Unlock() {
+		return s.ReplaySong(ctx)
+	}
+
+	s.GuessState.Skip()
+	s.recordRound(ctx)
+	if s.outOfLives() {
+		return s.endGame(ctx)
+	}
 
 	nextSong, err := s.MusicPlayer.NextInQueue()
+	if errors.Is(err, player.ErrQueueEnd) {
+		return s.endGame(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	// guessSong process:
+	track, err := s.setAnswer(nextSong)
 	if err != nil {
 		return err
 	}
-	track, ok := s.Cache.TrackMap[nextSong.TrackId]
+	s.recordRoundStart(ctx, nextSong)
+	return s.startSong(ctx, nextSong, track)
+}
+
+// setAnswer starts guessing the song with the fields known in the cache
+func (s *GameService) setAnswer(song player.Song) (spotify_api.TrackData, error) {
+	track, ok := s.Cache.TrackMap[song.TrackId]
 	if !ok {
-		panic("Track should always exist in cache")
+		return spotify_api.TrackData{}, fmt.Errorf("track %v is not cached", song.TrackId)
 	}
-	album, ok := s.Cache.AlbumMap[nextSong.AlbumId]
+	album, ok := s.Cache.AlbumMap[song.AlbumId]
 	if !ok {
-		panic("Track should always exist in cache")
+		return spotify_api.TrackData{}, fmt.Errorf("album %v of track %v is not cached", song.AlbumId, song.TrackId)
 	}
-	artist, ok := s.Cache.ArtistMap[nextSong.ArtistId]
+	artist, ok := s.Cache.ArtistMap[song.ArtistId]
 	if !ok {
-		panic("Track should always exist in cache")
+		return spotify_api.TrackData{}, fmt.Errorf("artist %v of track %v is not cached", song.ArtistId, song.TrackId)
 	}
 
-	// guessSong process:
-	s.GuessState.SetTitle(track.Name, artist.Name, album.ImagesURL)
-	s.MusicPlayer.Timer = time.Now()
-	s.MusicPlayer.SongDuration = time.Duration(track.DurationMs) * time.Millisecond
-
-	err = s.EnsureAccessToken(ctx)
-	if err != nil {
-		return fmt.Errorf("Couldnt not ensure refresh token")
+	answer := game.Answer{
+		Title:      track.Name,
+		Artist:     artist.Name,
+		AlbumImage: album.ImagesURL,
+	}
+	if len(track.Artists) > 1 {
+		answer.Featured = track.Artists[1:]
+	}
+	// top tracks are gathered in a fake album
+	if album.AlbumType != "TopTracks" {
+		answer.Album = album.Name
+		answer.Year = album.ReleaseDate
+	}
+	s.GuessState.SetAnswer(answer)
+	if s.Settings.MultipleChoice {
+		s.GuessState.SetChoices(s.choicesFor(song, track))
 	}
-	return s.SpotifyApi.PlaySong(s.SpotifyToken.AccessToken, nextSong.TrackId)
+	return track, nil
 }
 
 // ClearQueue clears the current music queue
-func (s *GameService) ClearQueue() error {
+func (s *GameService) ClearQueue(ctx context.Context) error {
+	s.recordAbandon(ctx)
 	s.AlbumSelection = make(map[string]bool)
 	s.ArtistSelection = make(map[string]uint8)
+	s.PlaylistSelection = make(map[string]bool)
+	s.SourceSelection = make(map[string]bool)
 	s.GuessState = game.NewGameState()
+	s.Rounds = nil
+	s.GameOver = false
 	s.MusicPlayer.ClearQueue()
-	s.SpotifyApi.PausePlayback(s.SpotifyToken.AccessToken)
+	s.stopClip()
+	s.SongProvider.PausePlayback(ctx, s.SpotifyToken.AccessToken, s.DeviceId)
 	return nil
 }
 
+// PausePlayback pauses the song on the game's device
+func (s *GameService) PausePlayback(ctx context.Context) error {
+	err := s.EnsureAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("Couldnt not ensure refresh token: %w", err)
+	}
+	return s.SongProvider.PausePlayback(ctx, s.SpotifyToken.AccessToken, s.DeviceId)
+}
+
+// GetDevices lists the Spotify Connect devices the game can play on
+func (s *GameService) GetDevices(ctx context.Context) ([]spotify_api.DeviceData, error) {
+	err := s.EnsureAccessToken(ctx)
+	if err != nil {
+		return []spotify_api.DeviceData{}, fmt.Errorf("No token spotify available: %w", err)
+	}
+	return s.SongProvider.FetchDevices(ctx, s.SpotifyToken.AccessToken)
+}
+
+// SelectDevice makes the game play on the device. Playback is transferred
+// to it and the current song, if any, starts over there
+func (s *GameService) SelectDevice(ctx context.Context, deviceId string) error {
+	devices, err := s.GetDevices(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(devices, func(device spotify_api.DeviceData) bool { return device.ID == deviceId }) {
+		return fmt.Errorf("unknown Spotify device: %v", deviceId)
+	}
+
+	err = s.SongProvider.TransferPlayback(ctx, s.SpotifyToken.AccessToken, deviceId, false)
+	if err != nil {
+		return err
+	}
+	s.DeviceId = deviceId
+
+	song, ok := s.MusicPlayer.CurrentSong()
+	if !ok {
+		return nil
+	}
+	// the clip starts over on the new device
+	s.MusicPlayer.Timer = time.Now()
+	return s.playSong(ctx, song.TrackId, s.MusicPlayer.ClipOffset)
+}
+
+// StreamURL is the address the browser plays the current track from, or
+// empty when the provider plays on its own device
+func (s *GameService) StreamURL() string {
+	if _, ok := s.SongProvider.(spotify_api.TrackStreamer); !ok {
+		return ""
+	}
+	if _, ok := s.MusicPlayer.CurrentSong(); !ok {
+		return ""
+	}
+	// the index and start time change the URL on every new song or replay
+	// so the browser reloads it
+	streamURL := fmt.Sprintf("/library/stream?n=%d&t=%d", s.MusicPlayer.CurrentIndex, s.MusicPlayer.Timer.UnixMilli())
+	if s.clipped() {
+		// the browser plays the file, so the clip is bounded by a media
+		// fragment and the stream ends after it
+		start := s.MusicPlayer.ClipOffset
+		end := start + s.MusicPlayer.SongDuration
+		streamURL += fmt.Sprintf("#t=%.3f,%.3f", start.Seconds(), end.Seconds())
+	}
+	return streamURL
+}
+
+// streamMargin is loaded past the end of a clip, for the bitrate changing
+// along the song and the codec setup after the tags
+const streamMargin = 32 * 1024
+
+// CurrentTrackStream returns the audio file of the song being played and how
+// many of its bytes the browser may load, 0 for all of them. In clip and
+// Heardle modes the file is cut after the part that may be heard, so it
+// can't be played further by editing the page
+func (s *GameService) CurrentTrackStream() (string, int64, error) {
+	streamer, ok := s.SongProvider.(spotify_api.TrackStreamer)
+	if !ok {
+		return "", 0, errors.New("song provider does not stream tracks")
+	}
+	song, ok := s.MusicPlayer.CurrentSong()
+	if !ok {
+		return "", 0, errors.New("no song is playing")
+	}
+	path, audioOffset, err := streamer.TrackPath(song.TrackId)
+	if err != nil {
+		return "", 0, err
+	}
+
+	track, ok := s.Cache.TrackMap[song.TrackId]
+	duration := time.Duration(track.DurationMs) * time.Millisecond
+	if !ok || duration <= 0 || !s.clipped() {
+		return path, 0, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// the audio is assumed to take the same bytes every second
+	end := s.MusicPlayer.ClipOffset + s.MusicPlayer.SongDuration
+	audioSize := info.Size() - audioOffset
+	limit := audioOffset + int64(float64(audioSize)*end.Seconds()/duration.Seconds()) + streamMargin
+	if limit >= info.Size() {
+		return path, 0, nil
+	}
+	return path, limit, nil
+}
+
+// AlbumCover returns the cover image of an album served by this server
+func (s *GameService) AlbumCover(albumId string) ([]byte, string, error) {
+	streamer, ok := s.SongProvider.(spotify_api.TrackStreamer)
+	if !ok {
+		return nil, "", errors.New("song provider does not serve covers")
+	}
+	return streamer.AlbumCover(albumId)
+}
+
+// RegisterBrowserDevice makes the game play in the page through the Web
+// Playback SDK device, which Spotify only lists once it is connected
+func (s *GameService) RegisterBrowserDevice(ctx context.Context, deviceId string) error {
+	err := s.EnsureAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("No token spotify available: %w", err)
+	}
+
+	err = s.SongProvider.TransferPlayback(ctx, s.SpotifyToken.AccessToken, deviceId, false)
+	if err != nil {
+		return err
+	}
+	s.BrowserDeviceId = deviceId
+	s.DeviceId = deviceId
+	return nil
+}
+
+// AccessToken returns a valid access token for the Web Playback SDK
+func (s *GameService) AccessToken(ctx context.Context) (string, error) {
+	err := s.EnsureAccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	return s.SpotifyToken.AccessToken, nil
+}
+
 func (s *GameService) RequestUserAuthoritazion() (string, error) {
-	urlString, err := s.SpotifyApi.AuthRequestURL()
+	urlString, err := s.SongProvider.AuthRequestURL()
 	return urlString, err
 }
 
@@ -246,11 +605,11 @@ func (s *GameService) ExchangeToken(ctx context.Context, state, code string) err
 	if code == "" || state == "" {
 		return fmt.Errorf("Error guetting code and state from spotify api")
 	}
-	err := s.SpotifyApi.ValidateState(state)
+	err := s.SongProvider.ValidateState(state)
 	if err != nil {
 		return err
 	}
-	spotiufyTokenReponse, err := s.SpotifyApi.TokenExchange(code)
+	spotiufyTokenReponse, err := s.SongProvider.TokenExchange(ctx, code)
 	if err != nil {
 		return err
 	}
@@ -275,6 +634,10 @@ func (s *GameService) ExchangeToken(ctx context.Context, state, code string) err
 }
 func (s *GameService) EnsureAccessToken(ctx context.Context) error {
 	fmt.Println("EnsureAccessToken called")
+	if !s.SongProvider.RequiresAuth() {
+		return nil
+	}
+
 	//Read from DB
 	if s.SpotifyToken.RefreshToken == "" {
 		fmt.Println("Empty Refresh token")
@@ -295,7 +658,7 @@ func (s *GameService) EnsureAccessToken(ctx context.Context) error {
 			return fmt.Errorf("Refresh token is empty")
 		}
 
-		spotifyRefreshReponse, err := s.SpotifyApi.RegenerateToken()
+		spotifyRefreshReponse, err := s.SongProvider.RegenerateToken(ctx)
 		if err != nil {
 			return err
 		}
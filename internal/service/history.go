@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/FerNunez/NameThatSong/internal/music_player"
+	"github.com/FerNunez/NameThatSong/internal/store"
+	"github.com/google/uuid"
+)
+
+// Outcomes of a round in the game history
+const (
+	OutcomeGuessed    = "guessed"
+	OutcomeTimedOut   = "timed out"
+	OutcomeOutOfLives = "out of lives"
+	OutcomeRevealed   = "revealed"
+	OutcomeSkipped    = "skipped"
+	// OutcomeAbandoned ends the round playing when the game is left
+	OutcomeAbandoned = "abandoned"
+)
+
+// Game modes in the game history
+const (
+	ModeNormal   = "normal"
+	ModeSurvival = "survival"
+)
+
+// recordGameStart adds the game starting to the player's history, the game
+// is played unrecorded when that fails. A game left unfinished is ended
+func (s *GameService) recordGameStart(ctx context.Context) {
+	s.recordAbandon(ctx)
+	if s.HistoryStore == nil {
+		return
+	}
+
+	mode := ModeNormal
+	if s.Settings.Lives > 0 {
+		mode = ModeSurvival
+	}
+	settings, err := json.Marshal(s.Settings)
+	if err != nil {
+		fmt.Printf("error recording game start: %v\n", err)
+		return
+	}
+	s.historyGameId, err = s.HistoryStore.StartGame(ctx, s.UserId, mode, settings)
+	if err != nil {
+		fmt.Printf("error recording game start: %v\n", err)
+	}
+}
+
+// recordRoundStart adds the song about to be guessed to the recorded game
+func (s *GameService) recordRoundStart(ctx context.Context, song player.Song) {
+	s.historyRoundId = uuid.Nil
+	if s.HistoryStore == nil || s.historyGameId == uuid.Nil {
+		return
+	}
+
+	var err error
+	s.historyRoundId, err = s.HistoryStore.StartRound(ctx, s.historyGameId, len(s.Rounds)+1, song.TrackId,
+		s.GuessState.Title.RealTitle, s.GuessState.Artist, s.GuessState.AlbumImage)
+	if err != nil {
+		fmt.Printf("error recording round start: %v\n", err)
+	}
+}
+
+// recordGuess adds a guess on the current song to the recorded round
+func (s *GameService) recordGuess(ctx context.Context, guess string, correct bool) {
+	if s.HistoryStore == nil || s.historyRoundId == uuid.Nil {
+		return
+	}
+	err := s.HistoryStore.RecordGuess(ctx, s.historyRoundId, guess, correct)
+	if err != nil {
+		fmt.Printf("error recording guess: %v\n", err)
+	}
+}
+
+// recordRoundEnd ends the recorded round with how the song went
+func (s *GameService) recordRoundEnd(ctx context.Context, result RoundResult) {
+	if s.HistoryStore == nil || s.historyRoundId == uuid.Nil {
+		return
+	}
+	err := s.HistoryStore.EndRound(ctx, s.historyRoundId, s.outcome(), result.Guessed, result.Score.Total())
+	if err != nil {
+		fmt.Printf("error recording round end: %v\n", err)
+	}
+	s.historyRoundId = uuid.Nil
+}
+
+// recordGameEnd ends the recorded game with its points
+func (s *GameService) recordGameEnd(ctx context.Context) {
+	if s.HistoryStore == nil || s.historyGameId == uuid.Nil {
+		return
+	}
+	err := s.HistoryStore.EndGame(ctx, s.historyGameId, s.GuessState.GetPoints())
+	if err != nil {
+		fmt.Printf("error recording game end: %v\n", err)
+	}
+	s.historyGameId = uuid.Nil
+}
+
+// recordAbandon ends the recorded game and its round playing when the game
+// is left before it is over
+func (s *GameService) recordAbandon(ctx context.Context) {
+	if s.HistoryStore == nil || s.historyGameId == uuid.Nil {
+		return
+	}
+	if s.historyRoundId != uuid.Nil {
+		err := s.HistoryStore.EndRound(ctx, s.historyRoundId, OutcomeAbandoned, s.GuessState.Guessed(), s.GuessState.Round().Total())
+		if err != nil {
+			fmt.Printf("error recording round end: %v\n", err)
+		}
+		s.historyRoundId = uuid.Nil
+	}
+	s.recordGameEnd(ctx)
+}
+
+// outcome is how the current song ended
+func (s *GameService) outcome() string {
+	switch {
+	case s.GuessState.Guessed():
+		return OutcomeGuessed
+	case s.GuessState.TimedOut():
+		return OutcomeTimedOut
+	case s.outOfLives():
+		return OutcomeOutOfLives
+	case s.GuessState.Revealed():
+		return OutcomeRevealed
+	}
+	return OutcomeSkipped
+}
+
+// History are the games the player played, the last first
+func (s *GameService) History(ctx context.Context) ([]store.GameRecord, error) {
+	if s.HistoryStore == nil {
+		return nil, errors.New("no game history")
+	}
+	return s.HistoryStore.ListGames(ctx, s.UserId)
+}
+
+// HistoryGame is one of the games the player played with its rounds and
+// guesses
+func (s *GameService) HistoryGame(ctx context.Context, gameId uuid.UUID) (store.GameRecord, []store.RoundRecord, error) {
+	if s.HistoryStore == nil {
+		return store.GameRecord{}, nil, errors.New("no game history")
+	}
+	return s.HistoryStore.GetGame(ctx, s.UserId, gameId)
+}
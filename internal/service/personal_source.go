@@ -0,0 +1,57 @@
+package service
+
+import "github.com/FerNunez/NameThatSong/internal/spotify_api"
+
+// Kinds of personal song sources built from the connected account
+const (
+	SourceLikedSongs = "liked"
+	SourceTopTracks  = "top-tracks"
+	SourceTopArtists = "top-artists"
+)
+
+// PersonalSource is a pool of songs the player actually knows, selectable
+// next to albums and playlists
+type PersonalSource struct {
+	ID        string
+	Kind      string
+	TimeRange string
+	Name      string
+}
+
+var timeRangeNames = map[string]string{
+	spotify_api.TimeRangeShort:  "last 4 weeks",
+	spotify_api.TimeRangeMedium: "last 6 months",
+	spotify_api.TimeRangeLong:   "last year",
+}
+
+// PersonalSources lists every personal source in display order
+var PersonalSources = personalSources()
+
+func personalSources() []PersonalSource {
+	sources := []PersonalSource{{ID: SourceLikedSongs, Kind: SourceLikedSongs, Name: "Liked Songs"}}
+	for _, kind := range []string{SourceTopTracks, SourceTopArtists} {
+		name := "Top tracks"
+		if kind == SourceTopArtists {
+			name = "Top artists"
+		}
+		for _, timeRange := range spotify_api.TimeRanges {
+			sources = append(sources, PersonalSource{
+				ID:        kind + ":" + timeRange,
+				Kind:      kind,
+				TimeRange: timeRange,
+				Name:      name + ", " + timeRangeNames[timeRange],
+			})
+		}
+	}
+	return sources
+}
+
+// FindPersonalSource returns the personal source with the given ID
+func FindPersonalSource(id string) (PersonalSource, bool) {
+	for _, source := range PersonalSources {
+		if source.ID == id {
+			return source, true
+		}
+	}
+	return PersonalSource{}, false
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/FerNunez/NameThatSong/internal/music_player"
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+)
+
+// ClipLengths are the clip lengths offered to the player, 0 plays whole songs
+var ClipLengths = []time.Duration{0, 5 * time.Second, 10 * time.Second, 15 * time.Second, 30 * time.Second}
+
+// MaxClipLength is the longest clip a game can be set to
+const MaxClipLength = 60 * time.Second
+
+// startSong plays the song, or only part of it in clip and Heardle modes.
+// Timer and SongDuration follow what is actually played, the title's points
+// decay from the start
+func (s *GameService) startSong(ctx context.Context, song player.Song, track spotify_api.TrackData) error {
+	duration := time.Duration(track.DurationMs) * time.Millisecond
+
+	offset := time.Duration(0)
+	length := duration
+	switch {
+	case s.GuessState.Heardle:
+		length = min(s.GuessState.UnlockedLength(), duration)
+	case s.Settings.ClipLength > 0 && s.Settings.ClipLength < duration:
+		offset = time.Duration(rand.Int64N(int64(duration - s.Settings.ClipLength)))
+		length = s.Settings.ClipLength
+	}
+
+	s.MusicPlayer.ClipOffset = offset
+	s.MusicPlayer.SongDuration = length
+	s.MusicPlayer.Timer = time.Now()
+	s.GuessState.StartTimer(s.MusicPlayer.Timer)
+	return s.playSong(ctx, song.TrackId, offset)
+}
+
+// ReplaySong plays again what the player may hear of the current song, from
+// its start in Heardle mode
+func (s *GameService) ReplaySong(ctx context.Context) error {
+	song, ok := s.MusicPlayer.CurrentSong()
+	if !ok {
+		return errors.New("no song is playing")
+	}
+
+	if s.GuessState.Heardle {
+		track, ok := s.Cache.TrackMap[song.TrackId]
+		if !ok {
+			return fmt.Errorf("track %v is not cached", song.TrackId)
+		}
+		duration := time.Duration(track.DurationMs) * time.Millisecond
+		s.MusicPlayer.SongDuration = min(s.GuessState.UnlockedLength(), duration)
+	}
+
+	s.MusicPlayer.Timer = time.Now()
+	return s.playSong(ctx, song.TrackId, s.MusicPlayer.ClipOffset)
+}
+
+// clipped tells whether only part of each song is played
+func (s *GameService) clipped() bool {
+	return s.Settings.ClipLength > 0 || s.GuessState.Heardle
+}
+
+// playSong starts the track at the offset on the game's device. When only
+// part of the song is played the server pauses it once SongDuration is over,
+// so it can't be heard further whatever the device or the page does
+func (s *GameService) playSong(ctx context.Context, trackId string, offset time.Duration) error {
+	s.stopClip()
+
+	err := s.EnsureAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("Couldnt not ensure refresh token: %w", err)
+	}
+	err = s.SongProvider.PlaySong(ctx, s.SpotifyToken.AccessToken, s.DeviceId, trackId, int(offset.Milliseconds()))
+	if err != nil {
+		return err
+	}
+
+	if s.clipped() {
+		s.scheduleClipStop(s.MusicPlayer.SongDuration)
+	}
+	return nil
+}
+
+// scheduleClipStop pauses playback after the delay. The pause runs outside
+// of any request, so it captures what it needs now
+func (s *GameService) scheduleClipStop(delay time.Duration) {
+	songProvider := s.SongProvider
+	accessToken := s.SpotifyToken.AccessToken
+	deviceId := s.DeviceId
+
+	s.clipStop = time.AfterFunc(delay, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := songProvider.PausePlayback(ctx, accessToken, deviceId)
+		if err != nil {
+			fmt.Printf("error pausing clip: %v\n", err)
+		}
+	})
+}
+
+// stopClip cancels the pause of the clip being played
+func (s *GameService) stopClip() {
+	if s.clipStop != nil {
+		s.clipStop.Stop()
+		s.clipStop = nil
+	}
+}
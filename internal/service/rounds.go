@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FerNunez/NameThatSong/internal/game"
+)
+
+// RoundResult is how a song of the game went, for the summary
+type RoundResult struct {
+	Title      string
+	Artist     string
+	AlbumImage string
+	Guessed    bool
+	// Elapsed is how long naming the title took, or how long the song was
+	// played when it wasn't named
+	Elapsed time.Duration
+	Score   game.RoundScore
+}
+
+// playRounds starts a game on the songs to play, shuffled and cut to the
+// rounds set
+func (s *GameService) playRounds(ctx context.Context) error {
+	s.MusicPlayer.ClearQueue()
+	for _, song := range s.TracksToPlayId {
+		s.MusicPlayer.Queue = append(s.MusicPlayer.Queue, *song)
+	}
+	s.MusicPlayer.Shuffle()
+	if rounds := s.Settings.Rounds; rounds > 0 && rounds < len(s.MusicPlayer.Queue) {
+		s.MusicPlayer.Queue = s.MusicPlayer.Queue[:rounds]
+	}
+
+	s.GuessState = game.NewGameState()
+	s.Rounds = nil
+	s.GameOver = false
+	s.applySettings()
+	s.recordGameStart(ctx)
+
+	song := s.MusicPlayer.Queue[s.MusicPlayer.CurrentIndex]
+	track, err := s.setAnswer(song)
+	if err != nil {
+		return err
+	}
+	s.recordRoundStart(ctx, song)
+	return s.startSong(ctx, song, track)
+}
+
+// PlayAgain starts a new game on the songs of the last one, reshuffled
+func (s *GameService) PlayAgain(ctx context.Context) error {
+	if len(s.TracksToPlayId) == 0 {
+		return errors.New("no game to play again")
+	}
+	err := s.Settings.Validate()
+	if err != nil {
+		return err
+	}
+	return s.playRounds(ctx)
+}
+
+// recordRound adds how the current song went to the game's rounds and its
+// history
+func (s *GameService) recordRound(ctx context.Context) {
+	score := s.GuessState.Round()
+	elapsed := score.Elapsed
+	if !s.GuessState.Guessed() {
+		elapsed = s.GuessState.Elapsed()
+	}
+
+	result := RoundResult{
+		Title:      s.GuessState.Title.RealTitle,
+		Artist:     s.GuessState.Artist,
+		AlbumImage: s.GuessState.AlbumImage,
+		Guessed:    s.GuessState.Guessed(),
+		Elapsed:    elapsed,
+		Score:      score,
+	}
+	s.recordRoundEnd(ctx, result)
+	s.Rounds = append(s.Rounds, result)
+}
+
+// endGame stops the music once the last song is over, survival scores are
+// recorded
+func (s *GameService) endGame(ctx context.Context) error {
+	s.GameOver = true
+	s.stopClip()
+	err := s.PausePlayback(ctx)
+	if err != nil {
+		fmt.Printf("error pausing the finished game: %v\n", err)
+	}
+	s.recordGameEnd(ctx)
+
+	if s.GuessState.Survival() && s.ScoreStore != nil {
+		err = s.ScoreStore.RecordSurvival(ctx, s.UserId, s.GuessState.Survived(), s.GuessState.GetPoints())
+		if err != nil {
+			fmt.Printf("error recording survival score: %v\n", err)
+		}
+		s.BestSurvived, err = s.ScoreStore.BestSurvival(ctx, s.UserId)
+		if err != nil {
+			fmt.Printf("error reading best survival score: %v\n", err)
+		}
+	}
+	return nil
+}
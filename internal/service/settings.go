@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/FerNunez/NameThatSong/internal/game"
+)
+
+// ErrInvalidSettings is returned when a game can't be played with the
+// settings chosen
+var ErrInvalidSettings = errors.New("invalid game settings")
+
+// MaxLives is the most lives a game can be set to
+const MaxLives = 10
+
+// GameSettings are what a game is played with, chosen before it starts
+type GameSettings struct {
+	// Rounds is how many songs the game lasts, 0 plays the whole selection
+	Rounds int `json:"rounds"`
+	// ClipLength, when set, plays a random segment of that length of each
+	// song instead of the whole song
+	ClipLength time.Duration `json:"clip_length"`
+	// Heardle unlocks each song progressively from its start
+	Heardle bool `json:"heardle"`
+	// MultipleChoice offers ChoiceCount titles to pick from instead of
+	// typing the title
+	MultipleChoice bool `json:"multiple_choice"`
+	// Fields are the game.OptionalFields asked besides the title, the
+	// artist and cover are shown from the start when the artist isn't
+	Fields []string `json:"fields"`
+	// Strictness is how forgiving guesses are, see game.Strictnesses
+	Strictness string `json:"strictness"`
+	// Language the titles are normalised in, see game.Pipelines
+	Language string `json:"language"`
+	// Scoring weighs the points of the game's songs
+	Scoring game.Scoring `json:"scoring"`
+	// Hints allows asking for hints at game.DefaultHintCosts
+	Hints bool `json:"hints"`
+	// Lives turn the survival mode on, each skipped, timed out or wrongly
+	// attributed song costs one. 0 plays a normal game
+	Lives int `json:"lives"`
+	// RoundTime is how long each song can be guessed in survival mode
+	RoundTime time.Duration `json:"round_time"`
+}
+
+// DefaultSettings are the settings of a player who never chose any
+func DefaultSettings() GameSettings {
+	return GameSettings{
+		Fields:     slices.Clone(game.OptionalFields),
+		Strictness: game.StrictnessNormal,
+		Language:   game.English.Language,
+		Scoring:    defaultScoring(),
+		Hints:      true,
+		RoundTime:  30 * time.Second,
+	}
+}
+
+// defaultScoring is game.DefaultScoring with field points of its own, so
+// loading saved settings doesn't change the defaults
+func defaultScoring() game.Scoring {
+	scoring := game.DefaultScoring
+	scoring.FieldPoints = maps.Clone(game.FieldPoints)
+	return scoring
+}
+
+// Validate tells why a game can't be played with the settings, if it can't
+func (s GameSettings) Validate() error {
+	if s.Rounds < 0 {
+		return fmt.Errorf("%w: rounds can't be negative", ErrInvalidSettings)
+	}
+	if s.ClipLength < 0 || s.ClipLength > MaxClipLength {
+		return fmt.Errorf("%w: clip length must be between 0 and %v", ErrInvalidSettings, MaxClipLength)
+	}
+	for _, field := range s.Fields {
+		if !slices.Contains(game.OptionalFields, field) {
+			return fmt.Errorf("%w: unknown answer field %v", ErrInvalidSettings, field)
+		}
+	}
+	if _, ok := game.Strictnesses[s.Strictness]; !ok {
+		return fmt.Errorf("%w: unknown strictness %v", ErrInvalidSettings, s.Strictness)
+	}
+	if _, ok := game.Pipelines[s.Language]; !ok {
+		return fmt.Errorf("%w: unknown language %v", ErrInvalidSettings, s.Language)
+	}
+
+	scoring := s.Scoring
+	if scoring.Grace < 0 || scoring.Decay < 0 || scoring.MinShare < 0 || scoring.MinShare > 1 {
+		return fmt.Errorf("%w: time decay must keep between 0 and all of the points", ErrInvalidSettings)
+	}
+	if scoring.StreakBonus < 0 || scoring.MaxMultiplier < 0 || scoring.SkipPenalty < 0 {
+		return fmt.Errorf("%w: streak bonus and skip penalty can't be negative", ErrInvalidSettings)
+	}
+	for field, points := range scoring.FieldPoints {
+		if points < 0 {
+			return fmt.Errorf("%w: the points of the %v can't be negative", ErrInvalidSettings, field)
+		}
+	}
+	if s.Lives < 0 || s.Lives > MaxLives {
+		return fmt.Errorf("%w: lives must be between 0 and %d", ErrInvalidSettings, MaxLives)
+	}
+	if s.RoundTime < 0 {
+		return fmt.Errorf("%w: round time can't be negative", ErrInvalidSettings)
+	}
+	return nil
+}
+
+// applySettings sets the guessing of the next songs up
+func (s *GameService) applySettings() {
+	settings := s.Settings
+	s.GuessState.SetHeardle(settings.Heardle)
+	s.GuessState.Pipeline = game.PipelineFor(settings.Language)
+	s.GuessState.Matcher = game.Strictnesses[settings.Strictness]
+	s.GuessState.Asked = settings.Fields
+	s.GuessState.ShowArtist = !slices.Contains(settings.Fields, game.FieldArtist)
+	s.GuessState.Scoring = settings.Scoring
+	s.GuessState.SetLives(settings.Lives)
+	s.GuessState.RoundTime = settings.RoundTime
+	s.GuessState.HintCosts = map[string]int{}
+	if settings.Hints {
+		s.GuessState.HintCosts = game.DefaultHintCosts
+	}
+}
+
+// LoadSettings picks the settings the player last started a game with, the
+// defaults are kept when there are none
+func (s *GameService) LoadSettings(ctx context.Context) error {
+	if s.SettingsStore == nil {
+		return nil
+	}
+	data, err := s.SettingsStore.Get(ctx, s.UserId)
+	if err != nil || data == nil {
+		return err
+	}
+
+	settings := DefaultSettings()
+	err = json.Unmarshal(data, &settings)
+	if err != nil {
+		return err
+	}
+	if settings.Validate() != nil {
+		return nil
+	}
+	s.Settings = settings
+	return nil
+}
+
+// saveSettings keeps the settings the game started with for the player's
+// next games
+func (s *GameService) saveSettings(ctx context.Context) error {
+	if s.SettingsStore == nil {
+		return nil
+	}
+	data, err := json.Marshal(s.Settings)
+	if err != nil {
+		return err
+	}
+	return s.SettingsStore.Save(ctx, s.UserId, data)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// CheckDeadline ends the current song once its survival deadline is over,
+// and the game with the last life
+func (s *GameService) CheckDeadline(ctx context.Context) error {
+	if s.GameOver || !s.GuessState.Timeout() {
+		return nil
+	}
+	return s.endIfOutOfLives(ctx)
+}
+
+// outOfLives tells whether the survival game lost its last life
+func (s *GameService) outOfLives() bool {
+	return s.GuessState.Survival() && s.GuessState.Lives() == 0
+}
+
+// endIfOutOfLives ends the game on the current song after its last life
+func (s *GameService) endIfOutOfLives(ctx context.Context) error {
+	if !s.outOfLives() {
+		return nil
+	}
+	s.GuessState.Reveal()
+	s.recordRound(ctx)
+	return s.endGame(ctx)
+}
+
+// namesOtherArtist tells whether the guess names an artist of the game's
+// songs other than the song's. Names whose words also name a word of the
+// answer don't count, e.g. the artist "Love" guessed for "Love Me Do"
+func (s *GameService) namesOtherArtist(guess string) bool {
+	pipeline := s.GuessState.Pipeline
+	words := " " + strings.Join(pipeline.Words(guess), " ") + " "
+	answer := strings.Join(pipeline.Words(s.GuessState.Artist), " ")
+
+	for _, artist := range s.gameArtists() {
+		nameWords := pipeline.Words(artist)
+		name := strings.Join(nameWords, " ")
+		if name == "" || name == answer || !strings.Contains(words, " "+name+" ") {
+			continue
+		}
+		if !slices.ContainsFunc(nameWords, s.GuessState.AnswerWord) {
+			return true
+		}
+	}
+	return false
+}
+
+// gameArtists are the names of the artists of the songs to play
+func (s *GameService) gameArtists() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, song := range s.TracksToPlayId {
+		artist, ok := s.Cache.ArtistMap[song.ArtistId]
+		if !ok || seen[song.ArtistId] {
+			continue
+		}
+		seen[song.ArtistId] = true
+		names = append(names, artist.Name)
+	}
+	return names
+}
@@ -0,0 +1,48 @@
+package spotify_api
+
+import "context"
+
+// SongProvider is a catalog of artists, albums and tracks that can also drive
+// playback. GameService and SpotifyCache only talk to this interface.
+type SongProvider interface {
+	// OAuth
+	RequiresAuth() bool
+	AuthRequestURL() (string, error)
+	ValidateState(state string) error
+	TokenExchange(ctx context.Context, code string) (TokenResponse, error)
+	RegenerateToken(ctx context.Context) (TokenResponse, error)
+
+	// Catalog
+	SearchArtistsByName(ctx context.Context, accessToken, name string) ([]ArtistData, error)
+	FetchAlbumByArtistID(ctx context.Context, accessToken, artistId string, groups []string) ([]AlbumData, error)
+	FetchTracksByAlbumID(ctx context.Context, accessToken, albumId string) ([]TrackData, error)
+	CreateAlbumFromTopTracks(ctx context.Context, accessToken, artistId string) (AlbumData, []TrackData, error)
+
+	// Playlists
+	FetchUserPlaylists(ctx context.Context, accessToken string) ([]PlaylistData, error)
+	FetchPlaylist(ctx context.Context, accessToken, playlistId string) (PlaylistData, error)
+	FetchPlaylistTracks(ctx context.Context, accessToken, playlistId string) ([]PlaylistTrack, error)
+
+	// Personal pools of the connected account
+	FetchSavedTracks(ctx context.Context, accessToken string) ([]PlaylistTrack, error)
+	FetchTopTracks(ctx context.Context, accessToken, timeRange string) ([]PlaylistTrack, error)
+	FetchTopArtists(ctx context.Context, accessToken, timeRange string) ([]ArtistData, error)
+
+	// Playback, an empty deviceId targets the active device
+	PlaySong(ctx context.Context, accessToken, deviceId, songID string, positionMs int) error
+	PausePlayback(ctx context.Context, accessToken, deviceId string) error
+	ResumePlayback(ctx context.Context, accessToken, deviceId string) error
+	FetchDevices(ctx context.Context, accessToken string) ([]DeviceData, error)
+	TransferPlayback(ctx context.Context, accessToken, deviceId string, play bool) error
+}
+
+// TrackStreamer is implemented by providers whose audio is served by this
+// server and played in the browser instead of on a Spotify device
+type TrackStreamer interface {
+	// TrackPath is the audio file of the track and the byte its audio
+	// starts at, after the tags
+	TrackPath(trackId string) (string, int64, error)
+	AlbumCover(albumId string) ([]byte, string, error)
+}
+
+var _ SongProvider = (*SpotifySongProvider)(nil)
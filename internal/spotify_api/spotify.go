@@ -1,5 +1,16 @@
 package spotify_api
 
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultAPIBaseURL      = "https://api.spotify.com"
+	DefaultAccountsBaseURL = "https://accounts.spotify.com"
+)
+
 type ArtistData struct {
 	Id         string
 	Name       string
@@ -15,16 +26,67 @@ type SpotifySongProvider struct {
 	State        string
 	AccessToken  string
 	RefreshToken string
+
+	// APIBaseURL and AccountsBaseURL can point to a stand-in server for
+	// staging and tests
+	APIBaseURL      string
+	AccountsBaseURL string
+	HTTPClient      *http.Client
+	Retry           RetryPolicy
+	Stats           *RetryStats
+
+	// MaxPagedItems caps how many albums or tracks are collected when
+	// following paging cursors, 0 means no cap
+	MaxPagedItems int
 }
 
-// NewSpotifySongProvider creates a new SpotifySongProvider
+// NewSpotifySongProvider creates a new SpotifySongProvider talking to the
+// real Spotify endpoints through the shared HTTP client
 func NewSpotifySongProvider(clientID, clientSecret string, redirectURI string, state string) *SpotifySongProvider {
 	return &SpotifySongProvider{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		RedirectURI:  redirectURI,
-		State:        state,
-		AccessToken:  "",
-		RefreshToken: "",
+		ClientID:        clientID,
+		ClientSecret:    clientSecret,
+		RedirectURI:     redirectURI,
+		State:           state,
+		AccessToken:     "",
+		RefreshToken:    "",
+		APIBaseURL:      DefaultAPIBaseURL,
+		AccountsBaseURL: DefaultAccountsBaseURL,
+		HTTPClient:      defaultHTTPClient,
+		Retry:           DefaultRetryPolicy(),
+		Stats:           DefaultRetryStats,
+		MaxPagedItems:   DefaultMaxPagedItems,
+	}
+}
+
+// defaultHTTPClient is shared by all providers so connections are reused
+var defaultHTTPClient = NewHTTPClient()
+
+// NewHTTPClient returns a client with timeouts suited to the Spotify API
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 15 * time.Second,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   5 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			MaxIdleConnsPerHost:   20,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
 	}
 }
+
+func (p *SpotifySongProvider) apiURL(path string) string {
+	return p.APIBaseURL + path
+}
+
+func (p *SpotifySongProvider) accountsURL(path string) string {
+	return p.AccountsBaseURL + path
+}
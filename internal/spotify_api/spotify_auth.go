@@ -1,6 +1,7 @@
 package spotify_api
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -18,9 +19,14 @@ type TokenResponse struct {
 	Scope        string `json:"scope"`
 }
 
+// RequiresAuth is always true: every Spotify call needs a user access token
+func (p *SpotifySongProvider) RequiresAuth() bool {
+	return true
+}
+
 func (p *SpotifySongProvider) AuthRequestURL() (string, error) {
 	// Build the authorization URL
-	authURL := "https://accounts.spotify.com/authorize"
+	authURL := p.accountsURL("/authorize")
 	u, err := url.Parse(authURL)
 	if err != nil {
 		return "", err
@@ -30,7 +36,7 @@ func (p *SpotifySongProvider) AuthRequestURL() (string, error) {
 	q := u.Query()
 	q.Set("response_type", "code")
 	q.Set("client_id", p.ClientID)
-	q.Set("scope", "user-read-private user-read-email streaming user-modify-playback-state user-read-playback-state")
+	q.Set("scope", "user-read-private user-read-email streaming user-modify-playback-state user-read-playback-state playlist-read-private playlist-read-collaborative user-library-read user-top-read")
 	q.Set("redirect_uri", p.RedirectURI)
 	q.Set("state", p.State)
 	u.RawQuery = q.Encode()
@@ -48,14 +54,14 @@ func (p *SpotifySongProvider) ValidateState(state string) error {
 }
 
 // Exchange code for tokens
-func (p *SpotifySongProvider) TokenExchange(code string) (TokenResponse, error) {
-	tokenURL := "https://accounts.spotify.com/api/token"
+func (p *SpotifySongProvider) TokenExchange(ctx context.Context, code string) (TokenResponse, error) {
+	tokenURL := p.accountsURL("/api/token")
 	data := url.Values{}
 	data.Set("grant_type", "authorization_code")
 	data.Set("code", code)
 	data.Set("redirect_uri", p.RedirectURI)
 
-	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		fmt.Printf("Error creating request: %v", err)
 		return TokenResponse{}, err
@@ -67,8 +73,7 @@ func (p *SpotifySongProvider) TokenExchange(code string) (TokenResponse, error)
 	req.Header.Set("Authorization", "Basic "+auth)
 
 	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.do(req)
 	if err != nil {
 		fmt.Printf("Error getting token: %v", err)
 		return TokenResponse{}, err
@@ -91,14 +96,14 @@ func (p *SpotifySongProvider) TokenExchange(code string) (TokenResponse, error)
 	return tokenResponse, nil
 }
 
-func (p *SpotifySongProvider) RegenerateToken() (TokenResponse, error) {
-	tokenURL := "https://accounts.spotify.com/api/token"
+func (p *SpotifySongProvider) RegenerateToken(ctx context.Context) (TokenResponse, error) {
+	tokenURL := p.accountsURL("/api/token")
 	data := url.Values{}
 	data.Set("grant_type", "refresh_token")
 	data.Set("refresh_token", p.RefreshToken)
 	data.Set("client_id", p.ClientID)
 
-	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		fmt.Printf("Error creating request: %v", err)
 		return TokenResponse{}, err
@@ -110,8 +115,7 @@ func (p *SpotifySongProvider) RegenerateToken() (TokenResponse, error) {
 	req.Header.Set("Authorization", "Basic "+auth)
 
 	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.do(req)
 	if err != nil {
 		fmt.Printf("Error getting token: %v", err)
 		return TokenResponse{}, err
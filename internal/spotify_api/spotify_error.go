@@ -0,0 +1,136 @@
+package spotify_api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SpotifyError is returned by every endpoint when Spotify answers with a
+// non-2xx status
+type SpotifyError struct {
+	StatusCode int
+	Message    string
+	Endpoint   string
+	RetryAfter time.Duration
+
+	// Reason is given by the player endpoints, e.g. NO_ACTIVE_DEVICE
+	Reason string
+}
+
+func (e *SpotifyError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("spotify %v: %d %v (retry after %v)", e.Endpoint, e.StatusCode, e.Message, e.RetryAfter)
+	}
+	return fmt.Sprintf("spotify %v: %d %v", e.Endpoint, e.StatusCode, e.Message)
+}
+
+// ReasonNoActiveDevice is the reason of a player command failing because no
+// device is playing and none was targeted
+const ReasonNoActiveDevice = "NO_ACTIVE_DEVICE"
+
+// NoActiveDevice tells whether a player command failed for lack of a device
+func (e *SpotifyError) NoActiveDevice() bool {
+	return e.Reason == ReasonNoActiveDevice
+}
+
+// IsNoActiveDevice tells whether err is a player command failing for lack of
+// a device
+func IsNoActiveDevice(err error) bool {
+	var spotifyErr *SpotifyError
+	return errors.As(err, &spotifyErr) && spotifyErr.NoActiveDevice()
+}
+
+// UserMessage explains the error to a player
+func (e *SpotifyError) UserMessage() string {
+	switch {
+	case e.NoActiveDevice():
+		return "No Spotify device is playing, pick one to play on"
+	case e.StatusCode == http.StatusUnauthorized:
+		return "Your Spotify session expired, please connect Spotify again"
+	case e.StatusCode == http.StatusForbidden:
+		return "Spotify refused the request: " + e.Message
+	case e.StatusCode == http.StatusNotFound:
+		return "Not found on Spotify"
+	case e.StatusCode == http.StatusTooManyRequests:
+		if e.RetryAfter > 0 {
+			return fmt.Sprintf("Spotify is busy, try again in %v", e.RetryAfter)
+		}
+		return "Spotify is busy, try again later"
+	case e.StatusCode >= 500:
+		return "Spotify is unavailable right now, try again later"
+	default:
+		return "Spotify error: " + e.Message
+	}
+}
+
+// HTTPStatus is the status to answer the browser with
+func (e *SpotifyError) HTTPStatus() int {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusTooManyRequests:
+		return e.StatusCode
+	}
+	if e.StatusCode >= 500 {
+		return http.StatusBadGateway
+	}
+	return http.StatusBadRequest
+}
+
+// newSpotifyError reads a failed response. The Web API answers
+// {"error": {"status", "message"}} while the accounts service answers
+// {"error", "error_description"}
+func newSpotifyError(resp *http.Response) *SpotifyError {
+	spotifyErr := &SpotifyError{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+		Endpoint:   resp.Request.Method + " " + resp.Request.URL.Path,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil || len(body) == 0 {
+		return spotifyErr
+	}
+
+	var errorResponse struct {
+		Error            json.RawMessage `json:"error"`
+		ErrorDescription string          `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &errorResponse); err != nil {
+		return spotifyErr
+	}
+
+	var apiError struct {
+		Message string `json:"message"`
+		Reason  string `json:"reason"`
+	}
+	var accountsError string
+	switch {
+	case json.Unmarshal(errorResponse.Error, &apiError) == nil && apiError.Message != "":
+		spotifyErr.Message = apiError.Message
+		spotifyErr.Reason = apiError.Reason
+	case errorResponse.ErrorDescription != "":
+		spotifyErr.Message = errorResponse.ErrorDescription
+	case json.Unmarshal(errorResponse.Error, &accountsError) == nil && accountsError != "":
+		spotifyErr.Message = accountsError
+	}
+	return spotifyErr
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as a date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
@@ -1,13 +1,27 @@
 package spotify_api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
+// Album groups an album can belong to relative to an artist
+const (
+	AlbumGroupAlbum       = "album"
+	AlbumGroupSingle      = "single"
+	AlbumGroupCompilation = "compilation"
+	AlbumGroupAppearsOn   = "appears_on"
+)
+
+// AlbumGroups lists every album group in the order they are displayed
+var AlbumGroups = []string{AlbumGroupAlbum, AlbumGroupSingle, AlbumGroupCompilation, AlbumGroupAppearsOn}
+
 type AlbumData struct {
 	AlbumType   string
+	AlbumGroup  string
 	TotalTracks int
 	ID          string
 	ImagesURL   string
@@ -15,93 +29,67 @@ type AlbumData struct {
 	ReleaseDate string
 }
 
-// fetch album by ID: retireves all songs
+// albumItem is a simplified album object of the Spotify API
+type albumItem struct {
+	AlbumType        string   `json:"album_type"`
+	TotalTracks      int      `json:"total_tracks"`
+	AvailableMarkets []string `json:"available_markets"`
+	ExternalUrls     struct {
+		Spotify string `json:"spotify"`
+	} `json:"external_urls"`
+	Href   string `json:"href"`
+	ID     string `json:"id"`
+	Images []struct {
+		URL    string `json:"url"`
+		Height int    `json:"height"`
+		Width  int    `json:"width"`
+	} `json:"images"`
+	Name                 string `json:"name"`
+	ReleaseDate          string `json:"release_date"`
+	ReleaseDatePrecision string `json:"release_date_precision"`
+	Type                 string `json:"type"`
+	URI                  string `json:"uri"`
+	Artists              []struct {
+		ExternalUrls struct {
+			Spotify string `json:"spotify"`
+		} `json:"external_urls"`
+		Href string `json:"href"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+		URI  string `json:"uri"`
+	} `json:"artists"`
+	AlbumGroup string `json:"album_group"`
+}
+
+// fetch albums by artist ID, following the pages: retireves all albums
 // https://api.spotify.com/v1/artists/{id}/albums&
 // id=album&
-// include_groups= album
+// include_groups= album,single,compilation,appears_on
 // limit=50
-func (p *SpotifySongProvider) FetchAlbumByArtistID(accesToken, artistId string) ([]AlbumData, error) {
+func (p *SpotifySongProvider) FetchAlbumByArtistID(ctx context.Context, accesToken, artistId string, groups []string) ([]AlbumData, error) {
 
 	limit := 50
-	include_groups := "album"
-	requestURL := fmt.Sprintf("https://api.spotify.com/v1/artists/%s/albums?limit=%d&include_groups=%v", artistId, limit, include_groups)
-	req, err := http.NewRequest("GET", requestURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accesToken))
-
-	client := http.Client{}
-	resp, err := client.Do(req)
+	include_groups := strings.Join(groups, ",")
+	requestURL := p.apiURL(fmt.Sprintf("/v1/artists/%s/albums?limit=%d&include_groups=%v", artistId, limit, include_groups))
+	items, err := fetchAllPages[albumItem](ctx, p, accesToken, requestURL)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, err
-	}
-
-	type FetchAlbumByArtistIDResponse struct {
-		Href     string `json:"href"`
-		Limit    int    `json:"limit"`
-		Next     string `json:"next"`
-		Offset   int    `json:"offset"`
-		Previous any    `json:"previous"`
-		Total    int    `json:"total"`
-		Items    []struct {
-			AlbumType        string   `json:"album_type"`
-			TotalTracks      int      `json:"total_tracks"`
-			AvailableMarkets []string `json:"available_markets"`
-			ExternalUrls     struct {
-				Spotify string `json:"spotify"`
-			} `json:"external_urls"`
-			Href   string `json:"href"`
-			ID     string `json:"id"`
-			Images []struct {
-				URL    string `json:"url"`
-				Height int    `json:"height"`
-				Width  int    `json:"width"`
-			} `json:"images"`
-			Name                 string `json:"name"`
-			ReleaseDate          string `json:"release_date"`
-			ReleaseDatePrecision string `json:"release_date_precision"`
-			Type                 string `json:"type"`
-			URI                  string `json:"uri"`
-			Artists              []struct {
-				ExternalUrls struct {
-					Spotify string `json:"spotify"`
-				} `json:"external_urls"`
-				Href string `json:"href"`
-				ID   string `json:"id"`
-				Name string `json:"name"`
-				Type string `json:"type"`
-				URI  string `json:"uri"`
-			} `json:"artists"`
-			AlbumGroup string `json:"album_group"`
-		} `json:"items"`
-	}
-
-	var albumsResponse FetchAlbumByArtistIDResponse
-	if err := json.NewDecoder(resp.Body).Decode(&albumsResponse); err != nil {
-		return nil, err
-	}
 
-	AlbumList := make([]AlbumData, 0, len(albumsResponse.Items))
-	for _, item := range albumsResponse.Items {
-		if item.AlbumType != "album" {
-			continue
-		}
-		if len(item.Images) <= 0 {
-			panic("spotify response of album with no images")
+	AlbumList := make([]AlbumData, 0, len(items))
+	for _, item := range items {
+		imageURL := ""
+		if len(item.Images) > 0 {
+			imageURL = item.Images[0].URL
 		}
 
 		album := AlbumData{
 			AlbumType:   item.AlbumType,
+			AlbumGroup:  item.AlbumGroup,
 			TotalTracks: item.TotalTracks,
 			ID:          item.ID,
-			ImagesURL:   item.Images[0].URL,
+			ImagesURL:   imageURL,
 			Name:        item.Name,
 			ReleaseDate: item.ReleaseDate,
 		}
@@ -116,76 +104,65 @@ type TrackData struct {
 	ID          string
 	Name        string
 	TrackNumber int
+	// Artists are the names of the track's artists, the primary one first
+	Artists []string
+}
+
+// artistItem is a simplified artist object of the Spotify API
+type artistItem struct {
+	ExternalUrls struct {
+		Spotify string `json:"spotify"`
+	} `json:"external_urls"`
+	Href string `json:"href"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	URI  string `json:"uri"`
+}
+
+// artistNames lists the names of the artists of a track
+func artistNames(artists []artistItem) []string {
+	names := make([]string, 0, len(artists))
+	for _, artist := range artists {
+		names = append(names, artist.Name)
+	}
+	return names
+}
+
+// trackItem is a simplified track object of the Spotify API
+type trackItem struct {
+	Artists          []artistItem `json:"artists"`
+	AvailableMarkets []string     `json:"available_markets"`
+	DiscNumber       int          `json:"disc_number"`
+	DurationMs       int          `json:"duration_ms"`
+	Explicit         bool         `json:"explicit"`
+	ExternalUrls     struct {
+		Spotify string `json:"spotify"`
+	} `json:"external_urls"`
+	Href        string `json:"href"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	PreviewURL  any    `json:"preview_url"`
+	TrackNumber int    `json:"track_number"`
+	Type        string `json:"type"`
+	URI         string `json:"uri"`
+	IsLocal     bool   `json:"is_local"`
 }
 
 // https://api.spotify.com/v1/albums/{id}/tracks
 // id= 4aawyAB9vmqN3uQ7FjRGTy
 // limit =50
-// fetch artists by ID:
-func (p *SpotifySongProvider) FetchTracksByAlbumID(accessToken, albumId string) ([]TrackData, error) {
+// fetch tracks by album ID, following the pages:
+func (p *SpotifySongProvider) FetchTracksByAlbumID(ctx context.Context, accessToken, albumId string) ([]TrackData, error) {
 	limit := 50
-	requestURL := fmt.Sprintf("https://api.spotify.com/v1/albums/%s/tracks?limit=%d", albumId, limit)
-	req, err := http.NewRequest("GET", requestURL, nil)
+	requestURL := p.apiURL(fmt.Sprintf("/v1/albums/%s/tracks?limit=%d", albumId, limit))
+	items, err := fetchAllPages[trackItem](ctx, p, accessToken, requestURL)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
-
-	client := http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, err
-	}
-
-	type FetchTracksByAlbumIDResponse struct {
-		Href  string `json:"href"`
-		Items []struct {
-			Artists []struct {
-				ExternalUrls struct {
-					Spotify string `json:"spotify"`
-				} `json:"external_urls"`
-				Href string `json:"href"`
-				ID   string `json:"id"`
-				Name string `json:"name"`
-				Type string `json:"type"`
-				URI  string `json:"uri"`
-			} `json:"artists"`
-			AvailableMarkets []string `json:"available_markets"`
-			DiscNumber       int      `json:"disc_number"`
-			DurationMs       int      `json:"duration_ms"`
-			Explicit         bool     `json:"explicit"`
-			ExternalUrls     struct {
-				Spotify string `json:"spotify"`
-			} `json:"external_urls"`
-			Href        string `json:"href"`
-			ID          string `json:"id"`
-			Name        string `json:"name"`
-			PreviewURL  any    `json:"preview_url"`
-			TrackNumber int    `json:"track_number"`
-			Type        string `json:"type"`
-			URI         string `json:"uri"`
-			IsLocal     bool   `json:"is_local"`
-		} `json:"items"`
-		Limit    int `json:"limit"`
-		Next     any `json:"next"`
-		Offset   int `json:"offset"`
-		Previous any `json:"previous"`
-		Total    int `json:"total"`
-	}
-
-	var tracksResponse FetchTracksByAlbumIDResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tracksResponse); err != nil {
-		return nil, err
-	}
-
-	trackList := make([]TrackData, 0, len(tracksResponse.Items))
-	for _, item := range tracksResponse.Items {
+	trackList := make([]TrackData, 0, len(items))
+	for _, item := range items {
 
 		track := TrackData{
 			DiscNumber:  item.DiscNumber,
@@ -193,33 +170,29 @@ func (p *SpotifySongProvider) FetchTracksByAlbumID(accessToken, albumId string)
 			ID:          item.ID,
 			Name:        item.Name,
 			TrackNumber: item.TrackNumber,
+			Artists:     artistNames(item.Artists),
 		}
 		trackList = append(trackList, track)
 	}
 	return trackList, nil
 }
 
-func (p *SpotifySongProvider) CreateAlbumFromTopTracks(accessToken, artistId string) (AlbumData, []TrackData, error) {
+func (p *SpotifySongProvider) CreateAlbumFromTopTracks(ctx context.Context, accessToken, artistId string) (AlbumData, []TrackData, error) {
 
-	requestURL := fmt.Sprintf("https://api.spotify.com/v1/artists/%v/top-tracks", artistId)
-	req, err := http.NewRequest("GET", requestURL, nil)
+	requestURL := p.apiURL(fmt.Sprintf("/v1/artists/%v/top-tracks", artistId))
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 	if err != nil {
 		return AlbumData{}, nil, err
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
 
-	client := http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.do(req)
 	if err != nil {
 		return AlbumData{}, nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return AlbumData{}, nil, err
-	}
-
 	type TopTracks struct {
 		Tracks []struct {
 			Album struct {
@@ -253,20 +226,11 @@ func (p *SpotifySongProvider) CreateAlbumFromTopTracks(accessToken, artistId str
 				Type                 string `json:"type"`
 				URI                  string `json:"uri"`
 			} `json:"album"`
-			Artists []struct {
-				ExternalUrls struct {
-					Spotify string `json:"spotify"`
-				} `json:"external_urls"`
-				Href string `json:"href"`
-				ID   string `json:"id"`
-				Name string `json:"name"`
-				Type string `json:"type"`
-				URI  string `json:"uri"`
-			} `json:"artists"`
-			AvailableMarkets []string `json:"available_markets"`
-			DiscNumber       int      `json:"disc_number"`
-			DurationMs       int      `json:"duration_ms"`
-			Explicit         bool     `json:"explicit"`
+			Artists          []artistItem `json:"artists"`
+			AvailableMarkets []string     `json:"available_markets"`
+			DiscNumber       int          `json:"disc_number"`
+			DurationMs       int          `json:"duration_ms"`
+			Explicit         bool         `json:"explicit"`
 			ExternalIds      struct {
 				Isrc string `json:"isrc"`
 			} `json:"external_ids"`
@@ -300,6 +264,7 @@ func (p *SpotifySongProvider) CreateAlbumFromTopTracks(accessToken, artistId str
 			ID:          item.ID,
 			Name:        item.Name,
 			TrackNumber: item.TrackNumber,
+			Artists:     artistNames(item.Artists),
 		}
 		trackList = append(trackList, track)
 	}
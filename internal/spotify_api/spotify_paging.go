@@ -0,0 +1,72 @@
+package spotify_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxPagedItems caps the items collected by following paging cursors
+const DefaultMaxPagedItems = 1000
+
+// pagingResponse is a Spotify paging object
+type pagingResponse[T any] struct {
+	Href     string `json:"href"`
+	Limit    int    `json:"limit"`
+	Next     string `json:"next"`
+	Offset   int    `json:"offset"`
+	Previous any    `json:"previous"`
+	Total    int    `json:"total"`
+	Items    []T    `json:"items"`
+}
+
+// fetchAllPages reads the paging object at requestURL and follows its next
+// cursors until every item is collected or MaxPagedItems is reached
+func fetchAllPages[T any](ctx context.Context, p *SpotifySongProvider, accessToken, requestURL string) ([]T, error) {
+	items := make([]T, 0)
+	for requestURL != "" {
+		page, err := fetchPage[T](ctx, p, accessToken, requestURL)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page.Items...)
+		if p.MaxPagedItems > 0 && len(items) >= p.MaxPagedItems {
+			return items[:p.MaxPagedItems], nil
+		}
+		requestURL = p.nextPageURL(page.Next)
+	}
+	return items, nil
+}
+
+func fetchPage[T any](ctx context.Context, p *SpotifySongProvider, accessToken, requestURL string) (pagingResponse[T], error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return pagingResponse[T]{}, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
+
+	resp, err := p.do(req)
+	if err != nil {
+		return pagingResponse[T]{}, err
+	}
+	defer resp.Body.Close()
+
+	var page pagingResponse[T]
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return pagingResponse[T]{}, err
+	}
+	return page, nil
+}
+
+// nextPageURL keeps following cursors on the configured API server, as
+// Spotify always answers with absolute api.spotify.com URLs
+func (p *SpotifySongProvider) nextPageURL(next string) string {
+	if rest, ok := strings.CutPrefix(next, DefaultAPIBaseURL); ok {
+		return p.apiURL(rest)
+	}
+	return next
+}
@@ -0,0 +1,105 @@
+package spotify_api
+
+import (
+	"context"
+	"fmt"
+)
+
+// Time ranges over which Spotify computes the user's top tracks and artists
+const (
+	TimeRangeShort  = "short_term"  // about the last 4 weeks
+	TimeRangeMedium = "medium_term" // about the last 6 months
+	TimeRangeLong   = "long_term"   // about the last year
+)
+
+// TimeRanges lists every time range from the most recent to the longest
+var TimeRanges = []string{TimeRangeShort, TimeRangeMedium, TimeRangeLong}
+
+// savedTrackItem is a saved track object of the Spotify API
+type savedTrackItem struct {
+	AddedAt string        `json:"added_at"`
+	Track   fullTrackItem `json:"track"`
+}
+
+// topArtistItem is an artist object of the Spotify API
+type topArtistItem struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Popularity int    `json:"popularity"`
+	Images     []struct {
+		URL    string `json:"url"`
+		Height int    `json:"height"`
+		Width  int    `json:"width"`
+	} `json:"images"`
+}
+
+// fetch the tracks in the user's Liked Songs, following the pages
+// https://api.spotify.com/v1/me/tracks
+// limit=50
+func (p *SpotifySongProvider) FetchSavedTracks(ctx context.Context, accessToken string) ([]PlaylistTrack, error) {
+	limit := 50
+	requestURL := p.apiURL(fmt.Sprintf("/v1/me/tracks?limit=%d", limit))
+	items, err := fetchAllPages[savedTrackItem](ctx, p, accessToken, requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	trackList := make([]PlaylistTrack, 0, len(items))
+	for _, item := range items {
+		if !item.Track.playable() {
+			continue
+		}
+		trackList = append(trackList, item.Track.playlistTrack())
+	}
+	return trackList, nil
+}
+
+// fetch the user's most listened tracks over the time range
+// https://api.spotify.com/v1/me/top/tracks
+// time_range=short_term|medium_term|long_term
+// limit=50
+func (p *SpotifySongProvider) FetchTopTracks(ctx context.Context, accessToken, timeRange string) ([]PlaylistTrack, error) {
+	limit := 50
+	requestURL := p.apiURL(fmt.Sprintf("/v1/me/top/tracks?time_range=%s&limit=%d", timeRange, limit))
+	items, err := fetchAllPages[fullTrackItem](ctx, p, accessToken, requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	trackList := make([]PlaylistTrack, 0, len(items))
+	for _, item := range items {
+		if !item.playable() {
+			continue
+		}
+		trackList = append(trackList, item.playlistTrack())
+	}
+	return trackList, nil
+}
+
+// fetch the user's most listened artists over the time range
+// https://api.spotify.com/v1/me/top/artists
+// time_range=short_term|medium_term|long_term
+// limit=50
+func (p *SpotifySongProvider) FetchTopArtists(ctx context.Context, accessToken, timeRange string) ([]ArtistData, error) {
+	limit := 50
+	requestURL := p.apiURL(fmt.Sprintf("/v1/me/top/artists?time_range=%s&limit=%d", timeRange, limit))
+	items, err := fetchAllPages[topArtistItem](ctx, p, accessToken, requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	artists := make([]ArtistData, 0, len(items))
+	for _, item := range items {
+		imageUrl := ""
+		if len(item.Images) > 0 {
+			imageUrl = item.Images[0].URL
+		}
+		artists = append(artists, ArtistData{
+			Id:         item.ID,
+			Name:       item.Name,
+			ImageUrl:   imageUrl,
+			Popularity: item.Popularity,
+		})
+	}
+	return artists, nil
+}
@@ -2,13 +2,16 @@ package spotify_api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
-// PlaySong starts playing a specific song on the user's active device
-func (p *SpotifySongProvider) PlaySong(accessToken, songID string) error {
+// PlaySong starts playing a specific song from positionMs on the given
+// device, or on the user's active device when deviceId is empty
+func (p *SpotifySongProvider) PlaySong(ctx context.Context, accessToken, deviceId, songID string, positionMs int) error {
 	type PlaySongRequest struct {
 		Uris       []string `json:"uris"`
 		PositionMs int      `json:"position_ms"`
@@ -16,7 +19,7 @@ func (p *SpotifySongProvider) PlaySong(accessToken, songID string) error {
 
 	psr := PlaySongRequest{
 		Uris:       []string{fmt.Sprintf("spotify:track:%v", songID)},
-		PositionMs: 0,
+		PositionMs: positionMs,
 	}
 
 	dat, err := json.Marshal(psr)
@@ -25,12 +28,9 @@ func (p *SpotifySongProvider) PlaySong(accessToken, songID string) error {
 	}
 
 	// Set request
-	url := "https://api.spotify.com/v1/me/player/play"
-	//if c.DeviceID != "" {
-	//	url = fmt.Sprintf("%s?device_id=%s", url, c.DeviceID)
-	//}
+	url := p.playerURL("/v1/me/player/play", deviceId)
 
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(dat))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(dat))
 	if err != nil {
 		return fmt.Errorf("could not create request: %v", err)
 	}
@@ -38,73 +38,52 @@ func (p *SpotifySongProvider) PlaySong(accessToken, songID string) error {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	client := http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.do(req)
 	if err != nil {
-		return fmt.Errorf("could not execute request: %v", err)
+		return fmt.Errorf("could not execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %v", resp.StatusCode)
-	}
-
 	return nil
 }
 
-// PausePlayback pauses the current playback on the user's active device
-func (p *SpotifySongProvider) PausePlayback(accessToken string) error {
-	url := "https://api.spotify.com/v1/me/player/pause"
-	//if c.DeviceID != "" {
-	//	url = fmt.Sprintf("%s?device_id=%s", url, c.DeviceID)
-	//}
+// PausePlayback pauses the current playback on the given or active device
+func (p *SpotifySongProvider) PausePlayback(ctx context.Context, accessToken, deviceId string) error {
+	url := p.playerURL("/v1/me/player/pause", deviceId)
 
-	req, err := http.NewRequest("PUT", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 	if err != nil {
 		return fmt.Errorf("could not create request: %v", err)
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
 
-	client := http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.do(req)
 	if err != nil {
-		return fmt.Errorf("could not execute request: %v", err)
+		return fmt.Errorf("could not execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %v", resp.StatusCode)
-	}
-
 	return nil
 }
 
-// ResumePlayback resumes the current playback on the user's active device
-func (p *SpotifySongProvider) ResumePlayback(accessToken string) error {
-	url := "https://api.spotify.com/v1/me/player/play"
-	// if c.DeviceID != "" {
-	// 	url = fmt.Sprintf("%s?device_id=%s", url, c.DeviceID)
-	// }
-	//
-	req, err := http.NewRequest("PUT", url, nil)
+// ResumePlayback resumes the current playback on the given or active device
+func (p *SpotifySongProvider) ResumePlayback(ctx context.Context, accessToken, deviceId string) error {
+	url := p.playerURL("/v1/me/player/play", deviceId)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 	if err != nil {
 		return fmt.Errorf("could not create request: %v", err)
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
 
-	client := http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.do(req)
 	if err != nil {
-		return fmt.Errorf("could not execute request: %v", err)
+		return fmt.Errorf("could not execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %v", resp.StatusCode)
-	}
-
 	return nil
 }
 
@@ -136,58 +115,99 @@ func (p *SpotifySongProvider) ResumePlayback(accessToken string) error {
 // 	return nil
 // }
 
-// GetActiveDevice gets the user's active Spotify device
-//func (c *SpotifyMusicServiceClient) GetActiveDevice() (string, error) {
-//	// If a device ID is already set, return it
-//	if c.DeviceID != "" {
-//		return c.DeviceID, nil
-//	}
-//
-//	// Otherwise, query the Spotify API for the user's devices
-//	req, err := http.NewRequest("GET", "https://api.spotify.com/v1/me/player/devices", nil)
-//	if err != nil {
-//		return "", fmt.Errorf("could not create request: %v", err)
-//	}
-//
-//	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", c.AccessToken))
-//
-//	client := http.Client{}
-//	resp, err := client.Do(req)
-//	if err != nil {
-//		return "", fmt.Errorf("could not execute request: %v", err)
-//	}
-//	defer resp.Body.Close()
-//
-//	if resp.StatusCode != http.StatusOK {
-//		return "", fmt.Errorf("unexpected status code: %v", resp.StatusCode)
-//	}
-//
-//	var devicesResponse struct {
-//		Devices []struct {
-//			ID            string `json:"id"`
-//			IsActive      bool   `json:"is_active"`
-//			Name          string `json:"name"`
-//			VolumePercent int    `json:"volume_percent"`
-//		} `json:"devices"`
-//	}
-//
-//	if err := json.NewDecoder(resp.Body).Decode(&devicesResponse); err != nil {
-//		return "", fmt.Errorf("could not decode response: %v", err)
-//	}
-//
-//	// Look for an active device
-//	for _, device := range devicesResponse.Devices {
-//		if device.IsActive {
-//			c.DeviceID = device.ID
-//			return device.ID, nil
-//		}
-//	}
-//
-//	// If no active device is found but there's at least one device, use the first one
-//	if len(devicesResponse.Devices) > 0 {
-//		c.DeviceID = devicesResponse.Devices[0].ID
-//		return devicesResponse.Devices[0].ID, nil
-//	}
-//
-//	return "", errors.New("no active device found")
-//}
+type DeviceData struct {
+	ID            string
+	Name          string
+	Type          string
+	IsActive      bool
+	IsRestricted  bool
+	VolumePercent int
+}
+
+// FetchDevices lists the Spotify Connect devices of the user
+// https://api.spotify.com/v1/me/player/devices
+func (p *SpotifySongProvider) FetchDevices(ctx context.Context, accessToken string) ([]DeviceData, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.apiURL("/v1/me/player/devices"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
+
+	resp, err := p.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var devicesResponse struct {
+		Devices []struct {
+			ID            string `json:"id"`
+			IsActive      bool   `json:"is_active"`
+			IsRestricted  bool   `json:"is_restricted"`
+			Name          string `json:"name"`
+			Type          string `json:"type"`
+			VolumePercent int    `json:"volume_percent"`
+		} `json:"devices"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&devicesResponse); err != nil {
+		return nil, fmt.Errorf("could not decode response: %v", err)
+	}
+
+	devices := make([]DeviceData, 0, len(devicesResponse.Devices))
+	for _, device := range devicesResponse.Devices {
+		// devices without an id can't be targeted
+		if device.ID == "" {
+			continue
+		}
+		devices = append(devices, DeviceData{
+			ID:            device.ID,
+			Name:          device.Name,
+			Type:          device.Type,
+			IsActive:      device.IsActive,
+			IsRestricted:  device.IsRestricted,
+			VolumePercent: device.VolumePercent,
+		})
+	}
+	return devices, nil
+}
+
+// TransferPlayback moves the user's playback to the device, starting it when
+// play is true
+// https://api.spotify.com/v1/me/player
+func (p *SpotifySongProvider) TransferPlayback(ctx context.Context, accessToken, deviceId string, play bool) error {
+	type TransferPlaybackRequest struct {
+		DeviceIds []string `json:"device_ids"`
+		Play      bool     `json:"play"`
+	}
+
+	dat, err := json.Marshal(TransferPlaybackRequest{DeviceIds: []string{deviceId}, Play: play})
+	if err != nil {
+		return fmt.Errorf("could not marshal request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", p.apiURL("/v1/me/player"), bytes.NewBuffer(dat))
+	if err != nil {
+		return fmt.Errorf("could not create request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.do(req)
+	if err != nil {
+		return fmt.Errorf("could not execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// playerURL targets the device when one is selected
+func (p *SpotifySongProvider) playerURL(path, deviceId string) string {
+	if deviceId == "" {
+		return p.apiURL(path)
+	}
+	return p.apiURL(path) + "?device_id=" + url.QueryEscape(deviceId)
+}
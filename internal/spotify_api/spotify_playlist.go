@@ -0,0 +1,217 @@
+package spotify_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type PlaylistData struct {
+	ID          string
+	Name        string
+	Owner       string
+	ImagesURL   string
+	TotalTracks int
+}
+
+// PlaylistTrack is a track of a playlist, or of the user's saved or top
+// tracks, with the album and primary artist it really belongs to
+type PlaylistTrack struct {
+	Track  TrackData
+	Album  AlbumData
+	Artist ArtistData
+}
+
+// playlistItem is a simplified playlist object of the Spotify API
+type playlistItem struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Images []struct {
+		URL    string `json:"url"`
+		Height int    `json:"height"`
+		Width  int    `json:"width"`
+	} `json:"images"`
+	Owner struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"display_name"`
+	} `json:"owner"`
+	Tracks struct {
+		Href  string `json:"href"`
+		Total int    `json:"total"`
+	} `json:"tracks"`
+	URI string `json:"uri"`
+}
+
+func (item playlistItem) playlistData() PlaylistData {
+	imageURL := ""
+	if len(item.Images) > 0 {
+		imageURL = item.Images[0].URL
+	}
+	owner := item.Owner.DisplayName
+	if owner == "" {
+		owner = item.Owner.ID
+	}
+	return PlaylistData{
+		ID:          item.ID,
+		Name:        item.Name,
+		Owner:       owner,
+		ImagesURL:   imageURL,
+		TotalTracks: item.Tracks.Total,
+	}
+}
+
+// playlistTrackItem is a playlist track object, its track is null when the
+// song was removed from the catalog and may be a podcast episode
+type playlistTrackItem struct {
+	AddedAt string         `json:"added_at"`
+	IsLocal bool           `json:"is_local"`
+	Track   *fullTrackItem `json:"track"`
+}
+
+// fullTrackItem is a track object of the Spotify API with its album
+type fullTrackItem struct {
+	trackItem
+	Album albumItem `json:"album"`
+}
+
+// playable tells whether the track can be played on a Spotify device
+func (item fullTrackItem) playable() bool {
+	return !item.IsLocal && item.Type == "track" && item.ID != ""
+}
+
+func (item fullTrackItem) playlistTrack() PlaylistTrack {
+	imageURL := ""
+	if len(item.Album.Images) > 0 {
+		imageURL = item.Album.Images[0].URL
+	}
+	artist := ArtistData{}
+	if len(item.Artists) > 0 {
+		artist = ArtistData{
+			Id:   item.Artists[0].ID,
+			Name: item.Artists[0].Name,
+		}
+	}
+
+	return PlaylistTrack{
+		Track: TrackData{
+			DiscNumber:  item.DiscNumber,
+			DurationMs:  item.DurationMs,
+			ID:          item.ID,
+			Name:        item.Name,
+			TrackNumber: item.TrackNumber,
+			Artists:     artistNames(item.Artists),
+		},
+		Album: AlbumData{
+			AlbumType:   item.Album.AlbumType,
+			AlbumGroup:  item.Album.AlbumGroup,
+			TotalTracks: item.Album.TotalTracks,
+			ID:          item.Album.ID,
+			ImagesURL:   imageURL,
+			Name:        item.Album.Name,
+			ReleaseDate: item.Album.ReleaseDate,
+		},
+		Artist: artist,
+	}
+}
+
+// fetch the playlists owned or followed by the current user, following the pages
+// https://api.spotify.com/v1/me/playlists
+// limit=50
+func (p *SpotifySongProvider) FetchUserPlaylists(ctx context.Context, accessToken string) ([]PlaylistData, error) {
+	limit := 50
+	requestURL := p.apiURL(fmt.Sprintf("/v1/me/playlists?limit=%d", limit))
+	items, err := fetchAllPages[playlistItem](ctx, p, accessToken, requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	playlists := make([]PlaylistData, 0, len(items))
+	for _, item := range items {
+		playlists = append(playlists, item.playlistData())
+	}
+	return playlists, nil
+}
+
+// https://api.spotify.com/v1/playlists/{id}
+func (p *SpotifySongProvider) FetchPlaylist(ctx context.Context, accessToken, playlistId string) (PlaylistData, error) {
+	requestURL := p.apiURL(fmt.Sprintf("/v1/playlists/%s?fields=id,name,images,owner(id,display_name),tracks(href,total),uri", url.PathEscape(playlistId)))
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return PlaylistData{}, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
+
+	resp, err := p.do(req)
+	if err != nil {
+		return PlaylistData{}, err
+	}
+	defer resp.Body.Close()
+
+	var item playlistItem
+	if err := json.NewDecoder(resp.Body).Decode(&item); err != nil {
+		return PlaylistData{}, err
+	}
+	return item.playlistData(), nil
+}
+
+// https://api.spotify.com/v1/playlists/{id}/tracks
+// limit=100
+// fetch the tracks of a playlist, following the pages. Local files, removed
+// songs and podcast episodes can't be played and are left out
+func (p *SpotifySongProvider) FetchPlaylistTracks(ctx context.Context, accessToken, playlistId string) ([]PlaylistTrack, error) {
+	limit := 100
+	requestURL := p.apiURL(fmt.Sprintf("/v1/playlists/%s/tracks?limit=%d", url.PathEscape(playlistId), limit))
+	items, err := fetchAllPages[playlistTrackItem](ctx, p, accessToken, requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	trackList := make([]PlaylistTrack, 0, len(items))
+	for _, item := range items {
+		if item.IsLocal || item.Track == nil || !item.Track.playable() {
+			continue
+		}
+		trackList = append(trackList, item.Track.playlistTrack())
+	}
+	return trackList, nil
+}
+
+var ErrInvalidPlaylist = errors.New("not a Spotify playlist link, URI or ID")
+
+// ParsePlaylistID extracts the playlist ID from a pasted playlist URL
+// (https://open.spotify.com/playlist/{id}?si=...), URI (spotify:playlist:{id})
+// or bare ID
+func ParsePlaylistID(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+
+	id := ref
+	if rest, ok := strings.CutPrefix(ref, "spotify:playlist:"); ok {
+		id = rest
+	} else if u, err := url.Parse(ref); err == nil && u.Host != "" {
+		// localised links look like /intl-fr/playlist/{id}
+		id = ""
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i := 0; i+1 < len(parts); i++ {
+			if parts[i] == "playlist" {
+				id = parts[i+1]
+				break
+			}
+		}
+	}
+
+	if id == "" {
+		return "", ErrInvalidPlaylist
+	}
+	for _, r := range id {
+		isBase62 := (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isBase62 {
+			return "", ErrInvalidPlaylist
+		}
+	}
+	return id, nil
+}
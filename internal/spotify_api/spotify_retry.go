@@ -0,0 +1,149 @@
+package spotify_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy controls how failed Spotify calls are retried
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// DefaultRetryPolicy retries a call up to 3 times, waiting at most 10s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+	}
+}
+
+// backoff is an exponential delay with full jitter
+func (r RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := r.BaseDelay << attempt
+	if ceiling <= 0 || ceiling > r.MaxDelay {
+		ceiling = r.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) + 1
+}
+
+// RetryStats counts the retried and the rate limited (429) Spotify calls
+type RetryStats struct {
+	retries   atomic.Int64
+	throttles atomic.Int64
+}
+
+// DefaultRetryStats is shared by all providers created with
+// NewSpotifySongProvider
+var DefaultRetryStats = &RetryStats{}
+
+func (s *RetryStats) Retries() int64 {
+	return s.retries.Load()
+}
+
+func (s *RetryStats) Throttles() int64 {
+	return s.throttles.Load()
+}
+
+// do sends the request and turns any non-2xx response into a SpotifyError.
+// 429s are retried after Retry-After, 5xx and network errors with backoff,
+// as long as the request context allows it. A Retry-After longer than
+// MaxDelay is returned straight away rather than holding the request
+func (p *SpotifySongProvider) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := p.HTTPClient.Do(attemptReq)
+		var wait time.Duration
+		switch {
+		case err != nil:
+			// the server may have processed a non idempotent request
+			if ctx.Err() != nil || req.Method == http.MethodPost {
+				return nil, err
+			}
+			wait = p.Retry.backoff(attempt)
+
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return resp, nil
+
+		case resp.StatusCode == http.StatusTooManyRequests:
+			spotifyErr := newSpotifyError(resp)
+			resp.Body.Close()
+			p.Stats.throttles.Add(1)
+			err = spotifyErr
+			wait = spotifyErr.RetryAfter
+			if wait > p.Retry.MaxDelay {
+				return nil, spotifyErr
+			}
+			if wait <= 0 {
+				wait = p.Retry.backoff(attempt)
+			}
+
+		case resp.StatusCode >= 500 && req.Method != http.MethodPost:
+			err = newSpotifyError(resp)
+			resp.Body.Close()
+			wait = p.Retry.backoff(attempt)
+
+		default:
+			defer resp.Body.Close()
+			return nil, newSpotifyError(resp)
+		}
+
+		if attempt >= p.Retry.MaxRetries || !waitRetry(ctx, wait) {
+			return nil, err
+		}
+		p.Stats.retries.Add(1)
+		fmt.Printf("spotify: retried %v %v after %v: %v (%d retries, %d throttled so far)\n",
+			req.Method, req.URL.Path, wait, err, p.Stats.Retries(), p.Stats.Throttles())
+	}
+}
+
+// rewindRequest returns a request with a fresh body for the given attempt
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("cannot retry request without GetBody")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retryReq := req.Clone(req.Context())
+	retryReq.Body = body
+	return retryReq, nil
+}
+
+// waitRetry sleeps for d unless the context ends, or would end, first
+func waitRetry(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,135 @@
+package spotify_api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+)
+
+// newTestProvider points a provider at the server with short retry delays
+func newTestProvider(server *httptest.Server) *spotify_api.SpotifySongProvider {
+	p := spotify_api.NewSpotifySongProvider("client", "secret", "http://localhost/callback", "state")
+	p.APIBaseURL = server.URL
+	p.AccountsBaseURL = server.URL
+	p.HTTPClient = server.Client()
+	p.Retry = spotify_api.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second}
+	p.Stats = &spotify_api.RetryStats{}
+	return p
+}
+
+func TestRetry(t *testing.T) {
+	testCases := []struct {
+		name string
+		// responses are the statuses answered in turn, the last one is
+		// repeated
+		responses  []int
+		retryAfter string
+		post       bool
+		requests   int64
+		status     int
+		throttles  int64
+	}{
+		{"success", []int{http.StatusOK}, "", false, 1, 0, 0},
+		{"429 waits for Retry-After", []int{http.StatusTooManyRequests, http.StatusOK}, "1", false, 2, 0, 1},
+		{"429 longer than MaxDelay", []int{http.StatusTooManyRequests, http.StatusOK}, "120", false, 1, http.StatusTooManyRequests, 1},
+		{"5xx backs off", []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK}, "", false, 3, 0, 0},
+		{"5xx gives up", []int{http.StatusInternalServerError}, "", false, 4, http.StatusInternalServerError, 0},
+		{"post isn't retried", []int{http.StatusBadGateway, http.StatusOK}, "", true, 1, http.StatusBadGateway, 0},
+		{"4xx isn't retried", []int{http.StatusNotFound, http.StatusOK}, "", false, 1, http.StatusNotFound, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1))
+				status := tc.responses[min(n, len(tc.responses))-1]
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(status)
+				if r.Method == http.MethodPost {
+					w.Write([]byte(`{"access_token": "token"}`))
+				} else {
+					w.Write([]byte(`{"devices": []}`))
+				}
+			}))
+			defer server.Close()
+			p := newTestProvider(server)
+
+			var err error
+			if tc.post {
+				_, err = p.TokenExchange(context.Background(), "code")
+			} else {
+				_, err = p.FetchDevices(context.Background(), "token")
+			}
+
+			if requests.Load() != tc.requests {
+				t.Errorf("requests = %d, want %d", requests.Load(), tc.requests)
+			}
+			var spotifyErr *spotify_api.SpotifyError
+			switch {
+			case tc.status == 0 && err != nil:
+				t.Errorf("err = %v, want none", err)
+			case tc.status != 0 && (!errors.As(err, &spotifyErr) || spotifyErr.StatusCode != tc.status):
+				t.Errorf("err = %v, want a SpotifyError with status %d", err, tc.status)
+			}
+			if p.Stats.Throttles() != tc.throttles {
+				t.Errorf("Throttles() = %d, want %d", p.Stats.Throttles(), tc.throttles)
+			}
+			if p.Stats.Retries() != tc.requests-1 {
+				t.Errorf("Retries() = %d, want %d", p.Stats.Retries(), tc.requests-1)
+			}
+		})
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	testCases := []struct {
+		name    string
+		context func() (context.Context, context.CancelFunc)
+	}{
+		{"cancelled", func() (context.Context, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			return ctx, cancel
+		}},
+		{"deadline before Retry-After", func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 500*time.Millisecond)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			defer server.Close()
+			p := newTestProvider(server)
+
+			ctx, cancel := tc.context()
+			defer cancel()
+			start := time.Now()
+			_, err := p.FetchDevices(ctx, "token")
+
+			if err == nil {
+				t.Fatalf("FetchDevices() succeeded, want an error")
+			}
+			if requests.Load() > 1 {
+				t.Errorf("requests = %d, want at most 1", requests.Load())
+			}
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("gave up after %v, want right away", elapsed)
+			}
+		})
+	}
+}
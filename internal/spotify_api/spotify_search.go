@@ -1,6 +1,7 @@
 package spotify_api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,13 +10,13 @@ import (
 	"strings"
 )
 
-func (p *SpotifySongProvider) SearchArtistsByName(accessToken, name string) ([]ArtistData, error) {
+func (p *SpotifySongProvider) SearchArtistsByName(ctx context.Context, accessToken, name string) ([]ArtistData, error) {
 
 	limit := "50"
 	artistQuery := "artist:" + strings.ToLower(name)
 
 	// Call Spotify API
-	apiURL, err := url.Parse("https://api.spotify.com/v1/search")
+	apiURL, err := url.Parse(p.apiURL("/v1/search"))
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +26,7 @@ func (p *SpotifySongProvider) SearchArtistsByName(accessToken, name string) ([]A
 	q.Set("limit", limit)
 	apiURL.RawQuery = q.Encode()
 
-	req, err := http.NewRequest("GET", apiURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -34,18 +35,13 @@ func (p *SpotifySongProvider) SearchArtistsByName(accessToken, name string) ([]A
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 
 	// Make the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.do(req)
 	if err != nil {
 		return nil, err
 
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, err
-	}
-
 	// Parse response
 	var searchArtistResponse struct {
 		Artists struct {
@@ -0,0 +1,61 @@
+package spotify_api_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FerNunez/NameThatSong/internal/spotify_api"
+)
+
+// TestBaseURLs points the provider at stand-in accounts and API servers
+func TestBaseURLs(t *testing.T) {
+	accounts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("client:secret"))
+		if r.Method != http.MethodPost || r.URL.Path != "/api/token" || r.Header.Get("Authorization") != auth {
+			http.Error(w, `{"error": "invalid_client"}`, http.StatusBadRequest)
+			return
+		}
+		if r.ParseForm() != nil || r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("code") != "code" {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"access_token": "access", "token_type": "Bearer", "expires_in": 3600, "refresh_token": "refresh"}`))
+	}))
+	defer accounts.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/search" || r.Header.Get("Authorization") != "Bearer access" {
+			http.Error(w, `{"error": {"status": 401, "message": "Invalid access token"}}`, http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"artists": {"items": [
+			{"id": "1", "name": "Queen", "popularity": 80},
+			{"id": "2", "name": "Queens of the Stone Age", "popularity": 90, "images": [{"url": "cover.jpg"}]}
+		]}}`))
+	}))
+	defer api.Close()
+
+	p := spotify_api.NewSpotifySongProvider("client", "secret", "http://localhost/callback", "state")
+	p.AccountsBaseURL = accounts.URL
+	p.APIBaseURL = api.URL
+	p.HTTPClient = http.DefaultClient
+
+	token, err := p.TokenExchange(context.Background(), "code")
+	if err != nil {
+		t.Fatalf("TokenExchange() = %v", err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" || token.ExpiresIn != 3600 {
+		t.Errorf("TokenExchange() = %+v", token)
+	}
+
+	artists, err := p.SearchArtistsByName(context.Background(), token.AccessToken, "queen")
+	if err != nil {
+		t.Fatalf("SearchArtistsByName() = %v", err)
+	}
+	if len(artists) != 2 || artists[0].Name != "Queens of the Stone Age" || artists[0].ImageUrl != "cover.jpg" {
+		t.Errorf("SearchArtistsByName() = %+v", artists)
+	}
+}
@@ -6,11 +6,52 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type Game struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Mode      string
+	Settings  json.RawMessage
+	StartedAt time.Time
+	EndedAt   sql.NullTime
+	Points    int32
+}
+
+type GameSetting struct {
+	UserID    uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Settings  json.RawMessage
+}
+
+type Guess struct {
+	ID        uuid.UUID
+	RoundID   uuid.UUID
+	CreatedAt time.Time
+	Guess     string
+	Correct   bool
+}
+
+type Round struct {
+	ID         uuid.UUID
+	GameID     uuid.UUID
+	Number     int32
+	TrackID    string
+	Title      string
+	Artist     string
+	AlbumImage string
+	StartedAt  time.Time
+	EndedAt    sql.NullTime
+	Outcome    string
+	Guessed    bool
+	Points     int32
+}
+
 type Session struct {
 	ID        string
 	CreatedAt time.Time
@@ -31,6 +72,14 @@ type SpotifyToken struct {
 	ExpiresAt    time.Time
 }
 
+type SurvivalScore struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	UserID        uuid.UUID
+	SongsSurvived int32
+	Points        int32
+}
+
 type User struct {
 	ID             uuid.UUID
 	CreatedAt      time.Time
@@ -0,0 +1,183 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/FerNunez/NameThatSong/internal/store/database"
+	"github.com/google/uuid"
+)
+
+// GameRecord is a game played by a user, EndedAt is zero while it isn't
+// over
+type GameRecord struct {
+	ID        uuid.UUID
+	Mode      string
+	StartedAt time.Time
+	EndedAt   time.Time
+	Points    int
+	Rounds    int
+	Guessed   int
+}
+
+// RoundRecord is a song of a game and the guesses made on it
+type RoundRecord struct {
+	Number     int
+	TrackId    string
+	Title      string
+	Artist     string
+	AlbumImage string
+	StartedAt  time.Time
+	EndedAt    time.Time
+	Outcome    string
+	Guessed    bool
+	Points     int
+	Guesses    []GuessRecord
+}
+
+type GuessRecord struct {
+	Guess     string
+	Correct   bool
+	CreatedAt time.Time
+}
+
+// HistoryStore records the games played, their rounds and guesses
+type HistoryStore interface {
+	StartGame(ctx context.Context, user_id uuid.UUID, mode string, settings []byte) (uuid.UUID, error)
+	EndGame(ctx context.Context, game_id uuid.UUID, points int) error
+	StartRound(ctx context.Context, game_id uuid.UUID, number int, track_id, title, artist, album_image string) (uuid.UUID, error)
+	EndRound(ctx context.Context, round_id uuid.UUID, outcome string, guessed bool, points int) error
+	RecordGuess(ctx context.Context, round_id uuid.UUID, guess string, correct bool) error
+	// ListGames are the user's games, the last first
+	ListGames(ctx context.Context, user_id uuid.UUID) ([]GameRecord, error)
+	// GetGame is one of the user's games with its rounds in order
+	GetGame(ctx context.Context, user_id, game_id uuid.UUID) (GameRecord, []RoundRecord, error)
+}
+
+// ////////////////////////////////////////////
+type SQLHistoryStore struct {
+	db *database.Queries
+}
+
+func NewSQLHistoryStore(db *database.Queries) HistoryStore {
+	return &SQLHistoryStore{db}
+}
+
+func (s *SQLHistoryStore) StartGame(ctx context.Context, user_id uuid.UUID, mode string, settings []byte) (uuid.UUID, error) {
+	dbGame, err := s.db.CreateGame(ctx, database.CreateGameParams{
+		UserID:   user_id,
+		Mode:     mode,
+		Settings: settings,
+	})
+	return dbGame.ID, err
+}
+
+func (s *SQLHistoryStore) EndGame(ctx context.Context, game_id uuid.UUID, points int) error {
+	return s.db.EndGame(ctx, database.EndGameParams{
+		Points: int32(points),
+		ID:     game_id,
+	})
+}
+
+func (s *SQLHistoryStore) StartRound(ctx context.Context, game_id uuid.UUID, number int, track_id, title, artist, album_image string) (uuid.UUID, error) {
+	dbRound, err := s.db.CreateRound(ctx, database.CreateRoundParams{
+		GameID:     game_id,
+		Number:     int32(number),
+		TrackID:    track_id,
+		Title:      title,
+		Artist:     artist,
+		AlbumImage: album_image,
+	})
+	return dbRound.ID, err
+}
+
+func (s *SQLHistoryStore) EndRound(ctx context.Context, round_id uuid.UUID, outcome string, guessed bool, points int) error {
+	return s.db.EndRound(ctx, database.EndRoundParams{
+		Outcome: outcome,
+		Guessed: guessed,
+		Points:  int32(points),
+		ID:      round_id,
+	})
+}
+
+func (s *SQLHistoryStore) RecordGuess(ctx context.Context, round_id uuid.UUID, guess string, correct bool) error {
+	return s.db.CreateGuess(ctx, database.CreateGuessParams{
+		RoundID: round_id,
+		Guess:   guess,
+		Correct: correct,
+	})
+}
+
+func (s *SQLHistoryStore) ListGames(ctx context.Context, user_id uuid.UUID) ([]GameRecord, error) {
+	dbGames, err := s.db.ListGamesByUser(ctx, user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]GameRecord, 0, len(dbGames))
+	for _, dbGame := range dbGames {
+		games = append(games, GameRecord{
+			ID:        dbGame.ID,
+			Mode:      dbGame.Mode,
+			StartedAt: dbGame.StartedAt,
+			EndedAt:   dbGame.EndedAt.Time,
+			Points:    int(dbGame.Points),
+			Rounds:    int(dbGame.Rounds),
+			Guessed:   int(dbGame.Guessed),
+		})
+	}
+	return games, nil
+}
+
+func (s *SQLHistoryStore) GetGame(ctx context.Context, user_id, game_id uuid.UUID) (GameRecord, []RoundRecord, error) {
+	dbGame, err := s.db.GetGameByID(ctx, database.GetGameByIDParams{ID: game_id, UserID: user_id})
+	if err != nil {
+		return GameRecord{}, nil, err
+	}
+	dbRounds, err := s.db.ListRoundsByGame(ctx, game_id)
+	if err != nil {
+		return GameRecord{}, nil, err
+	}
+	dbGuesses, err := s.db.ListGuessesByGame(ctx, game_id)
+	if err != nil {
+		return GameRecord{}, nil, err
+	}
+
+	guesses := make(map[uuid.UUID][]GuessRecord)
+	for _, dbGuess := range dbGuesses {
+		guesses[dbGuess.RoundID] = append(guesses[dbGuess.RoundID], GuessRecord{
+			Guess:     dbGuess.Guess,
+			Correct:   dbGuess.Correct,
+			CreatedAt: dbGuess.CreatedAt,
+		})
+	}
+
+	game := GameRecord{
+		ID:        dbGame.ID,
+		Mode:      dbGame.Mode,
+		StartedAt: dbGame.StartedAt,
+		EndedAt:   dbGame.EndedAt.Time,
+		Points:    int(dbGame.Points),
+		Rounds:    len(dbRounds),
+	}
+	rounds := make([]RoundRecord, 0, len(dbRounds))
+	for _, dbRound := range dbRounds {
+		if dbRound.Guessed {
+			game.Guessed++
+		}
+		rounds = append(rounds, RoundRecord{
+			Number:     int(dbRound.Number),
+			TrackId:    dbRound.TrackID,
+			Title:      dbRound.Title,
+			Artist:     dbRound.Artist,
+			AlbumImage: dbRound.AlbumImage,
+			StartedAt:  dbRound.StartedAt,
+			EndedAt:    dbRound.EndedAt.Time,
+			Outcome:    dbRound.Outcome,
+			Guessed:    dbRound.Guessed,
+			Points:     int(dbRound.Points),
+			Guesses:    guesses[dbRound.ID],
+		})
+	}
+	return game, rounds, nil
+}
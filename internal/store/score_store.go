@@ -0,0 +1,37 @@
+package store
+
+import (
+	"context"
+
+	"github.com/FerNunez/NameThatSong/internal/store/database"
+	"github.com/google/uuid"
+)
+
+// ScoreStore keeps the scores of survival games, apart from normal games
+type ScoreStore interface {
+	RecordSurvival(ctx context.Context, user_id uuid.UUID, survived, points int) error
+	// BestSurvival is the most songs the user survived, 0 without games
+	BestSurvival(ctx context.Context, user_id uuid.UUID) (int, error)
+}
+
+// ////////////////////////////////////////////
+type SQLScoreStore struct {
+	db *database.Queries
+}
+
+func NewSQLScoreStore(db *database.Queries) ScoreStore {
+	return &SQLScoreStore{db}
+}
+
+func (s *SQLScoreStore) RecordSurvival(ctx context.Context, user_id uuid.UUID, survived, points int) error {
+	return s.db.CreateSurvivalScore(ctx, database.CreateSurvivalScoreParams{
+		UserID:        user_id,
+		SongsSurvived: int32(survived),
+		Points:        int32(points),
+	})
+}
+
+func (s *SQLScoreStore) BestSurvival(ctx context.Context, user_id uuid.UUID) (int, error) {
+	best, err := s.db.GetBestSurvivalScore(ctx, user_id)
+	return int(best), err
+}
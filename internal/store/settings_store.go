@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/FerNunez/NameThatSong/internal/store/database"
+	"github.com/google/uuid"
+)
+
+// SettingsStore keeps the game settings each user last played with, as
+// JSON
+type SettingsStore interface {
+	// Get is nil when the user never saved settings
+	Get(ctx context.Context, user_id uuid.UUID) ([]byte, error)
+	Save(ctx context.Context, user_id uuid.UUID, settings []byte) error
+}
+
+// ////////////////////////////////////////////
+type SQLSettingsStore struct {
+	db *database.Queries
+}
+
+func NewSQLSettingsStore(db *database.Queries) SettingsStore {
+	return &SQLSettingsStore{db}
+}
+
+func (s *SQLSettingsStore) Get(ctx context.Context, user_id uuid.UUID) ([]byte, error) {
+	dbSettings, err := s.db.GetGameSettings(ctx, user_id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbSettings.Settings, nil
+}
+
+func (s *SQLSettingsStore) Save(ctx context.Context, user_id uuid.UUID, settings []byte) error {
+	return s.db.UpsertGameSettings(ctx, database.UpsertGameSettingsParams{
+		UserID:   user_id,
+		Settings: settings,
+	})
+}
@@ -0,0 +1,15 @@
+-- name: GetGameSettings :one
+SELECT * FROM game_settings
+WHERE user_id = $1;
+
+-- name: UpsertGameSettings :exec
+INSERT INTO game_settings (user_id, created_at, updated_at, settings)
+VALUES (
+  $1,
+  NOW(),
+  NOW(),
+  $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET settings = EXCLUDED.settings,
+    updated_at = NOW();
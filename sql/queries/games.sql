@@ -0,0 +1,31 @@
+-- name: CreateGame :one
+INSERT INTO games (id, user_id, mode, settings, started_at, points)
+VALUES (
+  gen_random_uuid(),
+  $1,
+  $2,
+  $3,
+  NOW(),
+  0
+)
+RETURNING *;
+
+-- name: EndGame :exec
+UPDATE games
+SET ended_at = NOW(),
+    points = $1
+WHERE id = $2;
+
+-- name: GetGameByID :one
+SELECT * FROM games
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListGamesByUser :many
+SELECT games.*,
+  COUNT(rounds.id)::INTEGER AS rounds,
+  (COUNT(rounds.id) FILTER (WHERE rounds.guessed))::INTEGER AS guessed
+FROM games
+LEFT JOIN rounds ON rounds.game_id = games.id
+WHERE games.user_id = $1
+GROUP BY games.id
+ORDER BY games.started_at DESC;
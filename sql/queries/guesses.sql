@@ -0,0 +1,15 @@
+-- name: CreateGuess :exec
+INSERT INTO guesses (id, round_id, created_at, guess, correct)
+VALUES (
+  gen_random_uuid(),
+  $1,
+  NOW(),
+  $2,
+  $3
+);
+
+-- name: ListGuessesByGame :many
+SELECT guesses.* FROM guesses
+JOIN rounds ON rounds.id = guesses.round_id
+WHERE rounds.game_id = $1
+ORDER BY guesses.created_at;
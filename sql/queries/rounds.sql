@@ -0,0 +1,27 @@
+-- name: CreateRound :one
+INSERT INTO rounds (id, game_id, number, track_id, title, artist, album_image, started_at, outcome)
+VALUES (
+  gen_random_uuid(),
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  NOW(),
+  'playing'
+)
+RETURNING *;
+
+-- name: EndRound :exec
+UPDATE rounds
+SET ended_at = NOW(),
+    outcome = $1,
+    guessed = $2,
+    points = $3
+WHERE id = $4;
+
+-- name: ListRoundsByGame :many
+SELECT * FROM rounds
+WHERE game_id = $1
+ORDER BY number;
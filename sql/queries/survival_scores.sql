@@ -0,0 +1,13 @@
+-- name: CreateSurvivalScore :exec
+INSERT INTO survival_scores (id, created_at, user_id, songs_survived, points)
+VALUES (
+  gen_random_uuid(),
+  NOW(),
+  $1,
+  $2,
+  $3
+);
+
+-- name: GetBestSurvivalScore :one
+SELECT COALESCE(MAX(songs_survived), 0)::INTEGER FROM survival_scores
+WHERE user_id = $1;
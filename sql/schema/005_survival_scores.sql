@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE survival_scores(
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  user_id UUID NOT NULL,
+  songs_survived INTEGER NOT NULL,
+  points INTEGER NOT NULL,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE survival_scores;
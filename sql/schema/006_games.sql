@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE games(
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL,
+  mode TEXT NOT NULL,
+  settings JSONB NOT NULL,
+  started_at TIMESTAMP NOT NULL,
+  ended_at TIMESTAMP,
+  points INTEGER NOT NULL DEFAULT 0,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE games;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE rounds(
+  id UUID PRIMARY KEY,
+  game_id UUID NOT NULL,
+  number INTEGER NOT NULL,
+  track_id TEXT NOT NULL,
+  title TEXT NOT NULL,
+  artist TEXT NOT NULL,
+  album_image TEXT NOT NULL,
+  started_at TIMESTAMP NOT NULL,
+  ended_at TIMESTAMP,
+  outcome TEXT NOT NULL,
+  guessed BOOLEAN NOT NULL DEFAULT FALSE,
+  points INTEGER NOT NULL DEFAULT 0,
+  FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE rounds;
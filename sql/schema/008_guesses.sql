@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE guesses(
+  id UUID PRIMARY KEY,
+  round_id UUID NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  guess TEXT NOT NULL,
+  correct BOOLEAN NOT NULL,
+  FOREIGN KEY (round_id) REFERENCES rounds(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE guesses;
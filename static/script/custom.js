@@ -51,8 +51,41 @@ document.addEventListener('DOMContentLoaded', function() {
     }
 });
 
+// Keep the local library audio element on the track announced by the music player
+function syncLocalPlayer() {
+    const player = document.getElementById('local-player');
+    const source = document.getElementById('stream-source');
+    if (!player) return;
+
+    const src = source ? source.dataset.src : '';
+    if (!src) {
+        player.pause();
+        player.removeAttribute('src');
+        return;
+    }
+    if (player.getAttribute('src') !== src) {
+        player.setAttribute('src', src);
+        player.play().catch(error => console.error('Error playing track:', error));
+    }
+}
+
+function toggleLocalPlayer() {
+    const player = document.getElementById('local-player');
+    if (!player || !player.getAttribute('src')) return;
+
+    if (player.paused) {
+        player.play();
+    } else {
+        player.pause();
+    }
+}
+
+document.addEventListener('DOMContentLoaded', syncLocalPlayer);
+
 // Add HTMX event listeners
 document.addEventListener('htmx:afterSwap', function(event) {
+    syncLocalPlayer();
+
     // Check if the album-dropdown-content was updated
     if (event.detail.target.id === 'album-dropdown-content') {
         const toggleButton = document.getElementById('toggle-album-button');
@@ -0,0 +1,38 @@
+// Plays the game in the page through the Spotify Web Playback SDK. The
+// server targets this device by its id, and the player state, which holds
+// the track metadata, is never put in the page.
+window.onSpotifyWebPlaybackSDKReady = function() {
+    const player = new Spotify.Player({
+        name: 'NameThatSong',
+        getOAuthToken: function(callback) {
+            fetch('/player/token')
+                .then(response => response.json())
+                .then(data => callback(data.access_token))
+                .catch(error => console.error('Error getting Spotify token:', error));
+        },
+        volume: 0.8
+    });
+
+    player.addListener('ready', function({ device_id }) {
+        fetch('/player/device', { method: 'POST', body: new URLSearchParams({ device_id: device_id }) })
+            .catch(error => console.error('Error registering browser player:', error));
+    });
+    player.addListener('not_ready', function() {
+        console.warn('Browser player went offline');
+    });
+    ['initialization_error', 'authentication_error', 'account_error', 'playback_error'].forEach(function(event) {
+        player.addListener(event, function({ message }) {
+            console.error('Browser player ' + event + ':', message);
+        });
+    });
+
+    player.connect();
+    window.webPlayer = player;
+};
+
+// Browsers only let the page play audio after the user interacted with it
+document.addEventListener('click', function() {
+    if (window.webPlayer) {
+        window.webPlayer.activateElement();
+    }
+});